@@ -1,53 +1,54 @@
-// Package config reads a yaml config file from the XDG_CONFIG_HOME and unmarshals it into a user supplied struct
+// Package config reads a config file from the XDG_CONFIG_HOME and unmarshals it into a user supplied struct.
+//
+// The user's file is $XDG_CONFIG_HOME/<program name>/config, optionally with an extension.
+// It is deep merged over system wide files in /etc/<program name> and $XDG_CONFIG_DIRS/<program name>,
+// and a project file ./.<program name>.yaml is merged over it; Sources reports which file supplied each key.
+// YAML, JSON, TOML and env files are supported; the format is chosen by extension or,
+// for a file named config, by sniffing its content. Further formats can be added with RegisterDecoder.
+//
+// Values are applied in order of increasing precedence: default tags (see ApplyDefaults),
+// the config file, environment variables (see ApplyEnv), then command line flags (see Load).
+// When no config file exists but environment variables with the program's prefix are set,
+// the config is built from the environment alone. The result is checked with Validate.
+//
+// Get, Load and Watch use a default Loader for each config type; create a Loader with
+// NewLoader for a different program name, file or set of decoders.
 package config
 
 import (
-	"errors"
-	"fmt"
-	"os"
-	"path/filepath"
-
-	"go.yaml.in/yaml/v4"
+	"reflect"
+	"sync"
 )
 
-var (
-	cached                any //nolint:gochecknoglobals
-	errInteraceConversion = errors.New("interface conversion")
-)
+//nolint:gochecknoglobals
+var loaders sync.Map // default Loader for each config type, keyed by reflect.Type
 
 // Get returns the configuration data for the supplied configuration struct type T, caching it after first retrieval.
+// It uses a default Loader for T, so different types can be used at once, eg for an application and a plugin.
 func Get[T any]() (*T, error) {
-	if cached == nil {
-		data, err := fromFile[T]()
-		if err != nil {
-			return nil, err
-		}
-		cached = data
-	}
-	data, ok := cached.(*T)
-	if !ok {
-		return data, fmt.Errorf("%w: wanted %T but cached type is %T",
-			errInteraceConversion, data, cached)
-	}
-	return data, nil
+	return defaultLoader[T]().Get()
 }
 
-// func fromFile reads the yaml configuration file and unmarshals it into a struct of type T
-// config file location is $XDG_CONFIG_HOME/executable name/config.
-func fromFile[T any]() (*T, error) {
-	progName := filepath.Base(os.Args[0])
-	xdg, err := os.UserConfigDir()
-	if err != nil {
-		return nil, fmt.Errorf("configuration dir %w", err)
-	}
-	cfgfile := xdg + "/" + progName + "/config"
-	bytes, err := os.ReadFile(cfgfile) //nolint:gosec
-	if err != nil {
-		return nil, fmt.Errorf("read config file %w", err)
-	}
-	var data T
-	if err := yaml.Unmarshal(bytes, &data); err != nil {
-		return nil, fmt.Errorf("unmarshal %w", err)
+// Reload reads the configuration data for T again and caches it if it is valid; see Loader.Reload.
+func Reload[T any]() (*T, error) {
+	return defaultLoader[T]().Reload()
+}
+
+// Invalidate drops the cached configuration data for T, so that the next Get reads it again.
+func Invalidate[T any]() {
+	defaultLoader[T]().Invalidate()
+}
+
+// Sources returns the file that supplied each key of the cached configuration data for T; see Loader.Sources.
+func Sources[T any]() map[string]string {
+	return defaultLoader[T]().Sources()
+}
+
+// defaultLoader returns the Loader used by Get, Load and Watch for the struct type T.
+func defaultLoader[T any]() *Loader[T] {
+	if l, ok := loaders.Load(reflect.TypeFor[T]()); ok {
+		return l.(*Loader[T]) //nolint:forcetypeassert
 	}
-	return &data, nil
+	l, _ := loaders.LoadOrStore(reflect.TypeFor[T](), NewLoader[T]())
+	return l.(*Loader[T]) //nolint:forcetypeassert
 }
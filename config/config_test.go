@@ -12,9 +12,9 @@ type testConfig struct {
 	Count int    `yaml:"count"`
 }
 
-// reset global cache between tests.
+// reset default loaders between tests.
 func resetCache() {
-	cached = nil
+	loaders.Clear()
 }
 
 // helper: write temp config file.
@@ -116,7 +116,7 @@ func TestGet_CacheReuse(t *testing.T) {
 	}
 }
 
-func TestGet_CacheTypeMismatch(t *testing.T) {
+func TestGet_CacheTypes(t *testing.T) {
 	defer resetCache()
 
 	progName := "mismatch"
@@ -129,18 +129,25 @@ func TestGet_CacheTypeMismatch(t *testing.T) {
 	defer func() { os.Args = origArgs }()
 
 	// first, populate cache with *testConfig
-	_, err := Get[testConfig]()
+	first, err := Get[testConfig]()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// now request with a different type
+	// now request with a different type, which has its own cache
 	type otherConfig struct {
-		Other string `yaml:"other"`
+		Name string `yaml:"name"`
 	}
-	_, err = Get[otherConfig]()
-	if err == nil {
-		t.Fatal("expected type mismatch error, got nil")
+	other, err := Get[otherConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.Name != "mismatch" {
+		t.Errorf("unexpected config values: %+v", other)
+	}
+	again, err := Get[testConfig]()
+	if err != nil || again != first {
+		t.Errorf("expected first config to stay cached, got %+v, %v", again, err)
 	}
 }
 
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errEnvValue        = errors.New("invalid environment value")
+	errUnsupportedType = errors.New("unsupported field type")
+	errNotStruct       = errors.New("not a pointer to a struct")
+)
+
+//nolint:gochecknoglobals
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// EnvPrefix returns the environment variable prefix for the program, its name in upper case
+// with other characters replaced by underscores, eg MY_PROG for my-prog.
+func EnvPrefix() string {
+	return envName(filepath.Base(os.Args[0]))
+}
+
+// ApplyEnv sets the fields of the struct pointed to by v from environment variables.
+//
+// A field is set from PREFIX_NAME where NAME is the field's env tag, else its yaml tag name,
+// else its Go name, in upper case; nested structs add their name to the prefix, so the Host
+// field of a Database field is MYPROG_DATABASE_HOST. Fields tagged env:"-" are skipped.
+//
+// Strings, bools, ints, uints, floats, time.Duration, types implementing encoding.TextUnmarshaler,
+// such as money.Money, and comma separated slices of those are supported. A TextUnmarshaler is
+// given the value as text, so a money.Money reads 12, 12.00 and $12 alike as dollars; the legacy
+// integer cents of YAML and JSON files do not apply.
+func ApplyEnv(v any, prefix string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", errNotStruct, v)
+	}
+	_, err := applyEnv(rv.Elem(), prefix)
+	return err
+}
+
+// applyEnv sets the fields of a struct value and reports whether any were set.
+func applyEnv(rv reflect.Value, prefix string) (bool, error) {
+	set := false
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		name := fieldName(field, "env")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		key := prefix
+		if !field.Anonymous || field.Tag.Get("env") != "" {
+			key = prefix + "_" + envName(name)
+		}
+		ok, err := applyEnvField(rv.Field(i), key)
+		if err != nil {
+			return set, err
+		}
+		set = set || ok
+	}
+	return set, nil
+}
+
+func applyEnvField(fv reflect.Value, key string) (bool, error) {
+	if isStruct(fv.Type()) {
+		return applyEnv(fv, key)
+	}
+	if fv.Kind() == reflect.Pointer && isStruct(fv.Type().Elem()) {
+		if fv.IsNil() {
+			if !hasEnv(key + "_") {
+				return false, nil
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return applyEnv(fv.Elem(), key)
+	}
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return false, nil
+	}
+	if err := setValue(fv, value); err != nil {
+		return false, fmt.Errorf("%w: %s=%q: %w", errEnvValue, key, value, err)
+	}
+	return true, nil
+}
+
+// hasEnv reports whether any environment variable starts with prefix.
+func hasEnv(prefix string) bool {
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStruct reports whether t is a struct that is set field by field rather than from a single value.
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue sets fv from its string representation.
+func setValue(fv reflect.Value, s string) error {
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) {
+		u, _ := fv.Addr().Interface().(encoding.TextUnmarshaler)
+		return u.UnmarshalText([]byte(s)) //nolint:wrapcheck
+	}
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	var err error
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 0, fv.Type().Bits())
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 0, fv.Type().Bits())
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, fv.Type().Bits())
+		fv.SetFloat(f)
+	case reflect.Slice:
+		err = setSlice(fv, s)
+	case reflect.Pointer:
+		elem := reflect.New(fv.Type().Elem())
+		if err = setValue(elem.Elem(), s); err == nil {
+			fv.Set(elem)
+		}
+	default:
+		err = fmt.Errorf("%w: %s", errUnsupportedType, fv.Type())
+	}
+	return err //nolint:wrapcheck
+}
+
+// setSlice sets a slice from comma separated values; an empty string gives an empty slice.
+func setSlice(fv reflect.Value, s string) error {
+	var parts []string
+	if strings.TrimSpace(s) != "" {
+		parts = strings.Split(s, ",")
+	}
+	slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+			return err
+		}
+	}
+	fv.Set(slice)
+	return nil
+}
+
+// fieldName returns the name of a struct field from the tag key, else its yaml tag, else its Go name.
+func fieldName(field reflect.StructField, key string) string {
+	for _, k := range []string{key, "yaml"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(k), ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// envName returns s in upper case with characters other than letters and digits replaced by underscores.
+func envName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
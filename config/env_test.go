@@ -0,0 +1,228 @@
+package config //nolint:testpackage
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+type envConfig struct {
+	Name    string        `yaml:"name"`
+	Port    uint16        `yaml:"port"`
+	Debug   bool          `yaml:"debug"`
+	Ratio   float64       `yaml:"ratio"`
+	Timeout time.Duration `yaml:"timeout"`
+	Tags    []string      `yaml:"tags"`
+	Price   money.Money   `yaml:"price"`
+	Limit   *int          `yaml:"limit"`
+	Secret  string        `env:"-"          yaml:"secret"`
+	APIKey  string        `env:"API_KEY"    yaml:"apiKey"`
+	Server  struct {
+		Host string `yaml:"host"`
+	} `yaml:"server"`
+	Cache *struct {
+		Size int `yaml:"size"`
+	} `yaml:"cache"`
+}
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv("APP_NAME", "env")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_DEBUG", "true")
+	t.Setenv("APP_RATIO", "0.5")
+	t.Setenv("APP_TIMEOUT", "1m30s")
+	t.Setenv("APP_TAGS", "a, b,c")
+	t.Setenv("APP_PRICE", "$1,234.56")
+	t.Setenv("APP_LIMIT", "7")
+	t.Setenv("APP_SECRET", "leaked")
+	t.Setenv("APP_API_KEY", "key")
+	t.Setenv("APP_SERVER_HOST", "example.com")
+	t.Setenv("APP_CACHE_SIZE", "64")
+
+	cfg := envConfig{Name: "file", Secret: "kept"}
+	if err := ApplyEnv(&cfg, "APP"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "env" || cfg.Port != 8080 || !cfg.Debug || cfg.Ratio != 0.5 {
+		t.Errorf("unexpected scalar values: %+v", cfg)
+	}
+	if cfg.Timeout != 90*time.Second {
+		t.Errorf("timeout = %v", cfg.Timeout)
+	}
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b", "c"}) {
+		t.Errorf("tags = %q", cfg.Tags)
+	}
+	if cfg.Price != money.Money(123456) {
+		t.Errorf("price = %v", cfg.Price)
+	}
+	if cfg.Limit == nil || *cfg.Limit != 7 {
+		t.Errorf("limit = %v", cfg.Limit)
+	}
+	if cfg.Secret != "kept" || cfg.APIKey != "key" {
+		t.Errorf("secret = %q, api key = %q", cfg.Secret, cfg.APIKey)
+	}
+	if cfg.Server.Host != "example.com" {
+		t.Errorf("server host = %q", cfg.Server.Host)
+	}
+	if cfg.Cache == nil || cfg.Cache.Size != 64 {
+		t.Errorf("cache = %+v", cfg.Cache)
+	}
+}
+
+func TestApplyEnv_Unset(t *testing.T) {
+	cfg := envConfig{Name: "file"}
+	if err := ApplyEnv(&cfg, "UNSET_PREFIX"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "file" || cfg.Cache != nil || cfg.Limit != nil {
+		t.Errorf("unset variables changed config: %+v", cfg)
+	}
+}
+
+func TestApplyEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name, key, value string
+	}{
+		{"int", "BAD_PORT", "many"},
+		{"overflow", "BAD_PORT", "70000"},
+		{"bool", "BAD_DEBUG", "maybe"},
+		{"duration", "BAD_TIMEOUT", "soon"},
+		{"money", "BAD_PRICE", "1.234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			err := ApplyEnv(&envConfig{}, "BAD")
+			if !errors.Is(err, errEnvValue) {
+				t.Fatalf("expected env value error, got %v", err)
+			}
+		})
+	}
+	if err := ApplyEnv(envConfig{}, "BAD"); !errors.Is(err, errNotStruct) {
+		t.Errorf("expected not struct error, got %v", err)
+	}
+}
+
+func TestGet_EnvOverridesFile(t *testing.T) {
+	useConfigFile(t, "env-prog", "config.yaml", "name: test\ncount: 42\ndatabase:\n  host: db\n  port: 5432\n")
+	t.Setenv("ENV_PROG_COUNT", "7")
+	t.Setenv("ENV_PROG_DATABASE_HOST", "override")
+
+	cfg, err := Get[nestedConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "test" || cfg.Count != 7 || cfg.Database.Host != "override" || cfg.Database.Port != 5432 {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestGet_EnvOnly(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origArgs := os.Args
+	os.Args = []string{"envonly"}
+	t.Cleanup(func() {
+		os.Args = origArgs
+		resetCache()
+	})
+	t.Setenv("ENVONLY_NAME", "from env")
+
+	cfg, err := Get[nestedConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "from env" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestEnvPrefix(t *testing.T) {
+	origArgs := os.Args
+	t.Cleanup(func() { os.Args = origArgs })
+	os.Args = []string{"/usr/local/bin/my-prog.v2"}
+	if got := EnvPrefix(); got != "MY_PROG_V2" {
+		t.Errorf("EnvPrefix() = %q", got)
+	}
+}
+
+type priceConfig struct {
+	Price money.Money `flag:"price" json:"price" toml:"price" yaml:"price"`
+}
+
+// TestMoneySources checks that files read bare integers as legacy cents, as stored data may be,
+// and that env files, environment variables, default tags and flags read them as dollars.
+func TestMoneySources(t *testing.T) {
+	for _, tt := range []struct {
+		text          string
+		file, literal money.Money
+	}{
+		{"1234", 1234, 123400},
+		{"12.5", 1250, 1250},
+		{"-7", -7, -700},
+	} {
+		text := tt.text
+		dir := t.TempDir()
+		sources := map[string]func() (*priceConfig, error){
+			"yaml": func() (*priceConfig, error) {
+				writeFile(t, filepath.Join(dir, "c.yaml"), "price: "+text+"\n")
+				return NewLoader[priceConfig](Path(filepath.Join(dir, "c.yaml")), ProgName("price")).Get()
+			},
+			"json": func() (*priceConfig, error) {
+				writeFile(t, filepath.Join(dir, "c.json"), `{"price": `+text+`}`)
+				return NewLoader[priceConfig](Path(filepath.Join(dir, "c.json")), ProgName("price")).Get()
+			},
+			"toml": func() (*priceConfig, error) {
+				writeFile(t, filepath.Join(dir, "c.toml"), "price = "+text+"\n")
+				return NewLoader[priceConfig](Path(filepath.Join(dir, "c.toml")), ProgName("price")).Get()
+			},
+			"env file": func() (*priceConfig, error) {
+				writeFile(t, filepath.Join(dir, "c.env"), "PRICE="+text+"\n")
+				return NewLoader[priceConfig](Path(filepath.Join(dir, "c.env")), ProgName("price")).Get()
+			},
+			"env": func() (*priceConfig, error) {
+				var cfg priceConfig
+				t.Setenv("PRICE_PRICE", text)
+				defer os.Unsetenv("PRICE_PRICE") //nolint:errcheck
+				return &cfg, ApplyEnv(&cfg, "PRICE")
+			},
+			"default": func() (*priceConfig, error) {
+				var cfg struct {
+					Price money.Money `default:"x"`
+				}
+				field, _ := reflect.TypeOf(cfg).FieldByName("Price")
+				field.Tag = reflect.StructTag(`default:"` + text + `"`)
+				v := reflect.New(reflect.StructOf([]reflect.StructField{field}))
+				err := ApplyDefaults(v.Interface())
+				return &priceConfig{Price: v.Elem().Field(0).Interface().(money.Money)}, err //nolint:forcetypeassert
+			},
+			"flag": func() (*priceConfig, error) {
+				var cfg priceConfig
+				fs := flag.NewFlagSet("price", flag.ContinueOnError)
+				if err := BindFlags(fs, &cfg); err != nil {
+					return nil, err
+				}
+				return &cfg, fs.Parse([]string{"-price", text})
+			},
+		}
+		for name, load := range sources {
+			cfg, err := load()
+			if err != nil {
+				t.Errorf("%s %s: unexpected error: %v", name, text, err)
+				continue
+			}
+			want := tt.literal
+			if name == "yaml" || name == "json" || name == "toml" {
+				want = tt.file
+			}
+			if cfg.Price != want {
+				t.Errorf("%s %s: got %v, want %v", name, text, cfg.Price, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var errDuplicateFlag = errors.New("duplicate flag")
+
+// Load returns the configuration data for the struct type T from the config file, environment
+// variables and command line flags, in order of increasing precedence, and caches it for Get.
+//
+// A flag is defined for every field with a flag tag, including fields of nested structs, eg
+//
+//	Port int `yaml:"port" flag:"port" usage:"port to listen on"`
+//
+// with the file and environment value as its default; only flags given on the command line
+// change the config. A missing config file is not an error. The result is checked with Validate.
+func Load[T any](opts ...Option) (*T, error) {
+	return defaultLoader[T]().Load(opts...)
+}
+
+// BindFlags defines a flag in fs for every field of the struct pointed to by v that has a flag tag.
+// The flag's usage comes from the usage tag and its default from the field's current value;
+// setting the flag sets the field, converting the value as ApplyEnv does.
+func BindFlags(fs *flag.FlagSet, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", errNotStruct, v)
+	}
+	return bindFlags(fs, rv.Elem().Type(), func(bool) reflect.Value { return rv.Elem() })
+}
+
+// bindFlags defines flags for the fields of the struct type t returned by get; get allocates nil
+// pointers to structs only when its argument is true, so that unset flags leave them nil.
+func bindFlags(fs *flag.FlagSet, t reflect.Type, get func(alloc bool) reflect.Value) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := fieldFunc(get, i)
+		name := field.Tag.Get("flag")
+		switch {
+		case name == "-":
+			continue
+		case name != "":
+			if fs.Lookup(name) != nil {
+				return fmt.Errorf("%w: %s", errDuplicateFlag, name)
+			}
+			fs.Var(&flagValue{typ: field.Type, field: fv, value: ""}, name, field.Tag.Get("usage"))
+		case isStruct(field.Type):
+			if err := bindFlags(fs, field.Type, fv); err != nil {
+				return err
+			}
+		case field.Type.Kind() == reflect.Pointer && isStruct(field.Type.Elem()):
+			if err := bindFlags(fs, field.Type.Elem(), elemFunc(fv)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setFlags sets the fields of the struct pointed to by v that are bound to the named flags,
+// as parsing the command line that gave those flags the values would.
+func setFlags(v any, flags map[string]string) error {
+	if len(flags) == 0 {
+		return nil
+	}
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	if err := BindFlags(fs, v); err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		if fs.Lookup(name) == nil {
+			continue // bound by the caller to some other struct
+		}
+		if err := fs.Set(name, flags[name]); err != nil {
+			return fmt.Errorf("flag -%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// fieldFunc returns a function that gets field i of the struct returned by get.
+func fieldFunc(get func(bool) reflect.Value, i int) func(bool) reflect.Value {
+	return func(alloc bool) reflect.Value {
+		s := get(alloc)
+		if !s.IsValid() {
+			return s
+		}
+		return s.Field(i)
+	}
+}
+
+// elemFunc returns a function that gets the value the pointer returned by get points to,
+// allocating it if alloc is true and the pointer is nil.
+func elemFunc(get func(bool) reflect.Value) func(bool) reflect.Value {
+	return func(alloc bool) reflect.Value {
+		p := get(alloc)
+		if !p.IsValid() || (p.IsNil() && !alloc) {
+			return reflect.Value{}
+		}
+		if p.IsNil() {
+			p.Set(reflect.New(p.Type().Elem()))
+		}
+		return p.Elem()
+	}
+}
+
+// flagValue is a flag.Value that sets a struct field.
+type flagValue struct {
+	typ   reflect.Type
+	field func(alloc bool) reflect.Value
+	value string // last value set
+}
+
+// String implements flag.Value.
+func (f *flagValue) String() string {
+	if f.field == nil {
+		return ""
+	}
+	return formatValue(f.field(false))
+}
+
+// Set implements flag.Value.
+func (f *flagValue) Set(s string) error {
+	if err := setValue(f.field(true), s); err != nil {
+		return err
+	}
+	f.value = s
+	return nil
+}
+
+// IsBoolFlag reports whether the field is a bool, so that -name is the same as -name=true.
+func (f *flagValue) IsBoolFlag() bool {
+	t := f.typ
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Bool
+}
+
+// formatValue returns v in the form accepted by setValue, or "" for the zero value so that
+// flag usage omits the default.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() || v.IsZero() {
+		return ""
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		return formatValue(v.Elem())
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
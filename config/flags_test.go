@@ -0,0 +1,123 @@
+package config //nolint:testpackage
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+type flagConfig struct {
+	Name    string        `flag:"name"    usage:"service name"   yaml:"name"`
+	Port    int           `flag:"port"    usage:"listen port"    yaml:"port"`
+	Debug   bool          `flag:"debug"   usage:"enable debug"   yaml:"debug"`
+	Timeout time.Duration `flag:"timeout" usage:"request timeout" yaml:"timeout"`
+	Price   money.Money   `flag:"price"   usage:"unit price"     yaml:"price"`
+	Tags    []string      `flag:"tags"    usage:"comma separated tags" yaml:"tags"`
+	Hidden  string        `yaml:"hidden"`
+	Server  struct {
+		Host string `flag:"host" usage:"server host" yaml:"host"`
+	} `yaml:"server"`
+	TLS *struct {
+		Cert string `flag:"cert" usage:"certificate file" yaml:"cert"`
+	} `yaml:"tls"`
+}
+
+func TestLoad_FlagsOverrideFile(t *testing.T) {
+	useConfigFile(t, "flagprog", "config.yaml", "name: file\nport: 80\nserver:\n  host: filehost\nhidden: secret\n")
+	t.Setenv("FLAGPROG_PORT", "81")
+
+	fs := flag.NewFlagSet("flagprog", flag.ContinueOnError)
+	extra := fs.String("extra", "", "caller defined flag")
+	cfg, err := Load[flagConfig](FlagSet(fs), Args([]string{
+		"-port", "8080", "-debug", "-timeout=2s", "-price", "$12.50", "-tags", "a,b",
+		"-host", "flaghost", "-extra", "x", "rest",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "file" || cfg.Hidden != "secret" {
+		t.Errorf("file values lost: %+v", cfg)
+	}
+	if cfg.Port != 8080 || !cfg.Debug || cfg.Timeout != 2*time.Second || cfg.Price != money.Money(1250) {
+		t.Errorf("flag values not applied: %+v", cfg)
+	}
+	if len(cfg.Tags) != 2 || cfg.Server.Host != "flaghost" {
+		t.Errorf("flag values not applied: %+v", cfg)
+	}
+	if cfg.TLS != nil {
+		t.Errorf("unset flag allocated nested struct: %+v", cfg.TLS)
+	}
+	if *extra != "x" || fs.Arg(0) != "rest" {
+		t.Errorf("extra = %q, args = %q", *extra, fs.Args())
+	}
+	if f := fs.Lookup("port"); f == nil || f.DefValue != "81" || f.Usage != "listen port" {
+		t.Errorf("port flag = %+v", f)
+	}
+	if fs.Lookup("hidden") != nil {
+		t.Error("untagged field bound to a flag")
+	}
+	got, err := Get[flagConfig]()
+	if err != nil || got != cfg {
+		t.Errorf("Get did not return loaded config: %v", err)
+	}
+}
+
+func TestLoad_NoFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origArgs := os.Args
+	os.Args = []string{"noflagfile"}
+	t.Cleanup(func() {
+		os.Args = origArgs
+		resetCache()
+	})
+
+	cfg, err := Load[flagConfig](Args([]string{"-cert", "server.pem"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLS == nil || cfg.TLS.Cert != "server.pem" {
+		t.Errorf("nested pointer flag not applied: %+v", cfg.TLS)
+	}
+}
+
+func TestLoad_InvalidFlag(t *testing.T) {
+	useConfigFile(t, "badflag", "config.yaml", "name: file\n")
+	fs := flag.NewFlagSet("badflag", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+
+	_, err := Load[flagConfig](FlagSet(fs), Args([]string{"-port", "eighty"}))
+	if err == nil || !strings.Contains(err.Error(), "-port") {
+		t.Errorf("expected invalid value error, got %v", err)
+	}
+	_, err = Load[flagConfig](FlagSet(flag.NewFlagSet("dup", flag.ContinueOnError)), Args(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := BindFlags(fs, &flagConfig{}); !errors.Is(err, errDuplicateFlag) {
+		t.Errorf("expected duplicate flag error, got %v", err)
+	}
+	if err := BindFlags(fs, flagConfig{}); !errors.Is(err, errNotStruct) {
+		t.Errorf("expected not struct error, got %v", err)
+	}
+}
+
+func TestBindFlags_Usage(t *testing.T) {
+	fs := flag.NewFlagSet("usage", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+	if err := BindFlags(fs, &flagConfig{Port: 80}); err != nil {
+		t.Fatal(err)
+	}
+	fs.PrintDefaults()
+	for _, want := range []string{"-debug\n", "listen port (default 80)", "-cert value"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("usage missing %q:\n%s", want, out.String())
+		}
+	}
+}
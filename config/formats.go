@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v4"
+)
+
+// Decoder unmarshals configuration data into the struct pointed to by v.
+type Decoder func(data []byte, v any) error
+
+var (
+	errUnknownFormat = errors.New("unknown config format")
+	errEnvSyntax     = errors.New("invalid env line")
+
+	//nolint:gochecknoglobals
+	registry = struct {
+		sync.RWMutex
+
+		decoders   map[string]Decoder
+		extensions []string // search order for config files
+	}{
+		decoders: map[string]Decoder{
+			".yaml": yaml.Unmarshal,
+			".yml":  yaml.Unmarshal,
+			".json": json.Unmarshal,
+			".toml": toml.Unmarshal,
+			".env":  decodeEnv,
+		},
+		extensions: []string{".yaml", ".yml", ".json", ".toml", ".env"},
+	}
+
+	tomlTable = regexp.MustCompile(`^\[\[?[\w.\-"' ]+\]\]?$`) //nolint:gochecknoglobals
+	tomlKey   = regexp.MustCompile(`^[\w.\-"']+\s*=`)         //nolint:gochecknoglobals
+)
+
+// RegisterDecoder registers a decoder for config files with the extension ext, eg ".hcl".
+// Registering an existing extension replaces its decoder; new extensions are searched after the built in ones.
+func RegisterDecoder(ext string, d Decoder) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.decoders[ext]; !ok {
+		registry.extensions = append(registry.extensions, ext)
+	}
+	registry.decoders[ext] = d
+}
+
+// extensions returns the config file extensions in search order.
+func extensions() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	return slices.Clone(registry.extensions)
+}
+
+// decoderFor returns the decoder for a config file, chosen by its extension or, for a file
+// without a registered extension, by sniffing its content.
+func decoderFor(path string, data []byte) (Decoder, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	registry.RLock()
+	defer registry.RUnlock()
+	if d, ok := registry.decoders[ext]; ok {
+		return d, nil
+	}
+	ext = sniff(data)
+	if d, ok := registry.decoders[ext]; ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errUnknownFormat, path)
+}
+
+// sniff guesses the format of data from its first significant line:
+// JSON starts with {, TOML with a [table] or key = value, and anything else is YAML.
+// Env files are only recognised by their .env extension.
+func sniff(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || line == "---":
+			continue
+		case strings.HasPrefix(line, "{"):
+			return ".json"
+		case tomlTable.MatchString(line) || tomlKey.MatchString(line):
+			return ".toml"
+		default:
+			return ".yaml"
+		}
+	}
+	return ".yaml"
+}
+
+// decodeEnv decodes an env file of KEY=value lines into v.
+// Keys are matched case insensitively against the yaml names of the fields of v, so that
+// MAXCONNS sets a field named maxConns, and a double underscore separates nested keys,
+// eg DATABASE__HOST=localhost sets database.host. Other keys, eg those of maps, are lower cased.
+// Values may be quoted and may be preceded by export. Values are plain text, as in environment
+// variables, so a money.Money reads 12 as dollars rather than as legacy cents.
+func decodeEnv(data []byte, v any) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%w: line %d: %q", errEnvSyntax, n, line)
+		}
+		value, err := unquote(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %w", errEnvSyntax, n, err)
+		}
+		setNode(root, strings.Split(strings.ToLower(key), "__"), value)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read env %w", err)
+	}
+	matchKeys(root, reflect.TypeOf(v))
+	if err := root.Decode(v); err != nil {
+		return fmt.Errorf("decode env %w", err)
+	}
+	return nil
+}
+
+// matchKeys renames the keys of a mapping node to the yaml names of the fields of t that they
+// match case insensitively, descending into nested structs, maps and slices.
+func matchKeys(node *yaml.Node, t reflect.Type) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		for i := 1; i < len(node.Content); i += 2 {
+			matchKeys(node.Content[i], t.Elem())
+		}
+	case reflect.Struct:
+		fields := yamlFields(t)
+		for i := 0; i < len(node.Content); i += 2 {
+			for name, ft := range fields {
+				if strings.EqualFold(node.Content[i].Value, name) {
+					node.Content[i].Value = name
+					matchKeys(node.Content[i+1], ft)
+					break
+				}
+			}
+		}
+	default:
+	}
+}
+
+// yamlFields returns the types of the fields of the struct type t keyed by their yaml names,
+// including the fields of inlined structs.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		name, opts, _ := strings.Cut(tag, ",")
+		switch {
+		case !field.IsExported() || name == "-":
+			continue
+		case slices.Contains(strings.Split(opts, ","), "inline"):
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				maps.Copy(fields, yamlFields(ft))
+			}
+		case name == "":
+			fields[strings.ToLower(field.Name)] = field.Type
+		default:
+			fields[name] = field.Type
+		}
+	}
+	return fields
+}
+
+func unquote(value string) (string, error) {
+	if len(value) < 2 { //nolint:mnd
+		return value, nil
+	}
+	switch value[0] {
+	case '"':
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("unquote %w", err)
+		}
+		return s, nil
+	case '\'':
+		if value[len(value)-1] == '\'' {
+			return value[1 : len(value)-1], nil
+		}
+	}
+	return value, nil
+}
+
+// setNode sets the value at path in a yaml mapping node, creating nested mappings as required.
+func setNode(node *yaml.Node, path []string, value string) {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+		if len(path) > 1 && node.Content[i+1].Kind == yaml.MappingNode {
+			setNode(node.Content[i+1], path[1:], value)
+			return
+		}
+		node.Content = slices.Delete(node.Content, i, i+2)
+		break
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}
+	if len(path) == 1 {
+		node.Content = append(node.Content, key, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	setNode(child, path[1:], value)
+	node.Content = append(node.Content, key, child)
+}
@@ -0,0 +1,130 @@
+package config //nolint:testpackage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type nestedConfig struct {
+	Name     string `json:"name"     toml:"name"     yaml:"name"`
+	Count    int    `json:"count"    toml:"count"    yaml:"count"`
+	Database struct {
+		Host string `json:"host" toml:"host" yaml:"host"`
+		Port int    `json:"port" toml:"port" yaml:"port"`
+	} `json:"database" toml:"database" yaml:"database"`
+}
+
+// helper: write temp config file with the given file name and point XDG_CONFIG_HOME and os.Args at it.
+func useConfigFile(t *testing.T, progName, fileName, content string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, progName), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, progName, fileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	origArgs := os.Args
+	os.Args = []string{progName}
+	t.Cleanup(func() {
+		os.Args = origArgs
+		resetCache()
+	})
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{"yaml", "config.yaml", "name: test\ncount: 42\ndatabase:\n  host: db\n  port: 5432\n"},
+		{"yml", "config.yml", "name: test\ncount: 42\ndatabase: {host: db, port: 5432}\n"},
+		{"json", "config.json", `{"name": "test", "count": 42, "database": {"host": "db", "port": 5432}}`},
+		{"toml", "config.toml", "name = \"test\"\ncount = 42\n[database]\nhost = \"db\"\nport = 5432\n"},
+		{"env", "config.env", "# comment\nNAME=test\nexport COUNT=42\nDATABASE__HOST=\"db\"\nDATABASE__PORT='5432'\n"},
+		{"sniff json", "config", `  {"name": "test", "count": 42, "database": {"host": "db", "port": 5432}}`},
+		{"sniff toml", "config", "# comment\nname = \"test\"\ncount = 42\n[database]\nhost = \"db\"\nport = 5432\n"},
+		{"sniff yaml", "config", "---\nname: test\ncount: 42\ndatabase:\n  host: db\n  port: 5432\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfigFile(t, "formats", tt.fileName, tt.content)
+			cfg, err := Get[nestedConfig]()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Name != "test" || cfg.Count != 42 || cfg.Database.Host != "db" || cfg.Database.Port != 5432 {
+				t.Errorf("unexpected config values: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestFormats_InvalidEnv(t *testing.T) {
+	useConfigFile(t, "badenv", "config.env", "NAME=test\nnot a pair\n")
+	_, err := Get[nestedConfig]()
+	if !errors.Is(err, errEnvSyntax) {
+		t.Fatalf("expected env syntax error, got %v", err)
+	}
+}
+
+func TestDecodeEnv_CamelCase(t *testing.T) {
+	type pool struct {
+		MaxConns int `yaml:"maxConns"`
+		Idle     int
+	}
+	var cfg struct {
+		Pool   pool            `yaml:"dbPool"`
+		Pools  map[string]pool `yaml:"pools"`
+		Inline pool            `yaml:",inline"`
+	}
+	data := "DBPOOL__MAXCONNS=5\nDBPOOL__IDLE=2\nPOOLS__Main__MaxConns=3\nmaxconns=7\n"
+	if err := decodeEnv([]byte(data), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Pool.MaxConns != 5 || cfg.Pool.Idle != 2 || cfg.Pools["main"].MaxConns != 3 || cfg.Inline.MaxConns != 7 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder(".upper", func(data []byte, v any) error {
+		cfg, ok := v.(*testConfig)
+		if !ok {
+			return errUnsupportedType
+		}
+		cfg.Name = strings.ToUpper(string(data))
+		return nil
+	})
+	useConfigFile(t, "custom", "config.upper", "shout")
+	cfg, err := Get[testConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "SHOUT" {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+}
+
+func TestSniff(t *testing.T) {
+	tests := map[string]string{
+		"{}":                 ".json",
+		"[server]\nport = 1": ".toml",
+		"[[servers]]":        ".toml",
+		"key = 'value'":      ".toml",
+		"key: value":         ".yaml",
+		"- a\n- b":           ".yaml",
+		"":                   ".yaml",
+	}
+	for content, want := range tests {
+		if got := sniff([]byte(content)); got != want {
+			t.Errorf("sniff(%q) = %s, want %s", content, got, want)
+		}
+	}
+}
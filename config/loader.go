@@ -0,0 +1,310 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Option configures a Loader, or a single call to Load.
+type Option func(*options)
+
+type options struct {
+	progName string
+	path     string
+	decoders map[string]Decoder
+	flags    *flag.FlagSet
+	args     []string
+	hasArgs  bool
+}
+
+// ProgName sets the program name, which names the config directory and the environment variable
+// prefix; the default is the base name of os.Args[0].
+func ProgName(name string) Option {
+	return func(o *options) {
+		o.progName = name
+	}
+}
+
+// Path sets the config file, instead of searching $XDG_CONFIG_HOME/<program name> for one.
+func Path(path string) Option {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+// WithDecoder adds a decoder for config files with the extension ext for this loader only,
+// taking precedence over decoders added with RegisterDecoder.
+func WithDecoder(ext string, d Decoder) Option {
+	return func(o *options) {
+		decoders := make(map[string]Decoder, len(o.decoders)+1)
+		for k, v := range o.decoders {
+			decoders[k] = v
+		}
+		decoders[ext] = d
+		o.decoders = decoders
+	}
+}
+
+// FlagSet sets the flag set that Load adds the struct's flags to, so that the caller can add
+// flags of their own and read the remaining arguments; the default is a new ContinueOnError
+// flag set named after the program.
+func FlagSet(fs *flag.FlagSet) Option {
+	return func(o *options) {
+		o.flags = fs
+	}
+}
+
+// Args sets the command line arguments parsed by Load; the default is os.Args[1:].
+func Args(args []string) Option {
+	return func(o *options) {
+		o.args = args
+		o.hasArgs = true
+	}
+}
+
+// Loader loads configuration data into a struct of type T and caches it.
+// A Loader is safe for concurrent use, and several may coexist in a process, eg for an
+// application and each of its plugins.
+type Loader[T any] struct {
+	opts  options
+	cache atomic.Pointer[state[T]]
+
+	mu    sync.Mutex
+	call  *call[T]          // read in progress, shared by concurrent callers
+	gen   uint64            // incremented by Invalidate and Load so that a read in progress is not cached
+	flags map[string]string // flags set on the command line by Load, reapplied by later reads
+}
+
+// state is a snapshot of the configuration data and the files that supplied it.
+type state[T any] struct {
+	data    *T
+	sources map[string]string // key path to file
+}
+
+// call is a read of the configuration data shared by concurrent callers.
+type call[T any] struct {
+	done    chan struct{}
+	data    *state[T]
+	err     error
+	waiters int // callers waiting for the read besides the one making it, guarded by Loader.mu
+}
+
+// NewLoader returns a Loader for the struct type T configured by opts.
+func NewLoader[T any](opts ...Option) *Loader[T] {
+	l := &Loader[T]{}
+	for _, opt := range opts {
+		opt(&l.opts)
+	}
+	return l
+}
+
+// Get returns the configuration data, reading it on first use and caching it; see Get.
+// Concurrent first calls share one read and its error.
+func (l *Loader[T]) Get() (*T, error) {
+	if s := l.cache.Load(); s != nil {
+		return s.data, nil
+	}
+	return l.do(false)
+}
+
+// Reload reads the configuration data again and caches it if it is valid; on error the
+// last good config stays cached. A read already in progress may predate the change that
+// prompted the call, so Reload waits for it and then reads again, sharing that read with
+// calls made meanwhile. If the data is unchanged the cached pointer is returned.
+func (l *Loader[T]) Reload() (*T, error) {
+	return l.do(true)
+}
+
+// Invalidate drops the cached configuration data, so that the next Get reads it again.
+// A read in progress is returned to its callers but not cached.
+func (l *Loader[T]) Invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gen++
+	l.cache.Store(nil)
+}
+
+// do reads and caches the configuration data, unless it is cached and not forced, or joins a read in progress.
+// A forced call does not join a read that started before it, but waits for that read to finish.
+func (l *Loader[T]) do(force bool) (*T, error) {
+	stale := force // a read in progress started before this call
+	l.mu.Lock()
+	for {
+		if s := l.cache.Load(); s != nil && !force {
+			l.mu.Unlock()
+			return s.data, nil
+		}
+		c := l.call
+		if c == nil {
+			break
+		}
+		c.waiters++
+		l.mu.Unlock()
+		<-c.done
+		if !stale {
+			return c.result()
+		}
+		stale = false
+		l.mu.Lock()
+	}
+	c := &call[T]{done: make(chan struct{})}
+	l.call = c
+	gen, flags := l.gen, l.flags
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.call = nil
+		close(c.done)
+	}()
+	c.data, c.err = l.read(flags)
+	if c.err == nil {
+		l.mu.Lock()
+		if old := l.cache.Load(); old != nil && reflect.DeepEqual(old.data, c.data.data) {
+			c.data.data = old.data
+		}
+		if gen == l.gen {
+			l.cache.Store(c.data)
+		}
+		l.mu.Unlock()
+	}
+	return c.result()
+}
+
+// result returns the data read by the call.
+func (c *call[T]) result() (*T, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.data.data, nil
+}
+
+// read loads the configuration data, sets the flags given to Load and validates the result.
+func (l *Loader[T]) read(flags map[string]string) (*state[T], error) {
+	s, err := l.load(l.opts, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := setFlags(s.data, flags); err != nil {
+		return nil, err
+	}
+	if err := Validate(s.data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Sources returns the file that supplied each key of the cached configuration data, keyed
+// by the key's dotted path as written in the files, eg database.port. Keys set by defaults,
+// environment variables or flags are not included, nor are keys from files whose decoder
+// cannot decode into a map. Sources returns nil if no data is cached.
+func (l *Loader[T]) Sources() map[string]string {
+	s := l.cache.Load()
+	if s == nil {
+		return nil
+	}
+	return maps.Clone(s.sources)
+}
+
+// Load reads the configuration data, applies command line flags and caches the result; see Load.
+// The opts apply to this call only, on top of the loader's own. The flags set on the command line
+// are kept and set again by later reads, eg by Reload or Watch, so that they keep precedence.
+func (l *Loader[T]) Load(opts ...Option) (*T, error) {
+	o := l.opts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.hasArgs {
+		o.args = os.Args[1:]
+	}
+	if o.flags == nil {
+		o.flags = flag.NewFlagSet(o.name(), flag.ContinueOnError)
+	}
+	s, err := l.load(o, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := BindFlags(o.flags, s.data); err != nil {
+		return nil, err
+	}
+	if err := o.flags.Parse(o.args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+	if err := Validate(s.data); err != nil {
+		return nil, err
+	}
+	flags := map[string]string{}
+	o.flags.Visit(func(f *flag.Flag) {
+		if v, ok := f.Value.(*flagValue); ok {
+			flags[f.Name] = v.value
+		}
+	})
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gen++
+	l.flags = flags
+	l.cache.Store(s)
+	return s.data, nil
+}
+
+// load applies defaults, reads the config files and applies environment variable overrides.
+// A missing config file is an error unless missingOK or environment variables with the program's prefix are set.
+func (l *Loader[T]) load(o options, missingOK bool) (*state[T], error) {
+	prefix := envName(o.name())
+	data := new(T)
+	if err := ApplyDefaults(data); err != nil {
+		return nil, err
+	}
+	sources, err := o.fromFiles(data)
+	if errors.Is(err, os.ErrNotExist) && (missingOK || hasEnv(prefix+"_")) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyEnv(data, prefix); err != nil {
+		return nil, err
+	}
+	return &state[T]{data: data, sources: sources}, nil
+}
+
+// name returns the program name.
+func (o options) name() string {
+	if o.progName != "" {
+		return o.progName
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// extensions returns the config file extensions in search order, registered ones followed by the loader's own.
+func (o options) extensions() []string {
+	exts := extensions()
+	n := len(exts)
+	for ext := range o.decoders {
+		if !slices.Contains(exts, ext) {
+			exts = append(exts, ext)
+		}
+	}
+	slices.Sort(exts[n:])
+	return exts
+}
+
+// decoderFor returns the decoder for a config file, chosen by its extension or, for a file
+// without a known extension, by sniffing its content.
+func (o options) decoderFor(path string, data []byte) (Decoder, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if d, ok := o.decoders[ext]; ok {
+		return d, nil
+	}
+	return decoderFor(path, data)
+}
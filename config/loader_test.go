@@ -0,0 +1,335 @@
+package config //nolint:testpackage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type appConfig struct {
+	Name string `yaml:"name"`
+	Port int    `flag:"port" yaml:"port"`
+}
+
+type pluginConfig struct {
+	Plugin string `yaml:"plugin"`
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoader_ProgName(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	writeFile(t, filepath.Join(dir, "app", "config.yaml"), "name: app\nport: 80\n")
+	writeFile(t, filepath.Join(dir, "plugin", "config.json"), `{"plugin": "p1"}`)
+	t.Setenv("APP_PORT", "81")
+
+	app := NewLoader[appConfig](ProgName("app"))
+	plugin := NewLoader[pluginConfig](ProgName("plugin"))
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if cfg, err := app.Get(); err != nil || cfg.Name != "app" || cfg.Port != 81 {
+				t.Errorf("app config %+v, %v", cfg, err)
+			}
+		})
+		wg.Go(func() {
+			if cfg, err := plugin.Get(); err != nil || cfg.Plugin != "p1" {
+				t.Errorf("plugin config %+v, %v", cfg, err)
+			}
+		})
+	}
+	wg.Wait()
+	first, _ := app.Get()
+	second, _ := app.Get()
+	if first != second {
+		t.Error("expected same cached pointer, got different")
+	}
+}
+
+func TestLoader_Path(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.yml")
+	writeFile(t, path, "name: explicit\nport: 1\n")
+	l := NewLoader[appConfig](Path(path), ProgName("pathprog"))
+	cfg, err := l.Get()
+	if err != nil || cfg.Name != "explicit" {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+
+	missing := NewLoader[appConfig](Path(filepath.Join(t.TempDir(), "none.yaml")), ProgName("pathprog"))
+	if _, err := missing.Get(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+func TestLoader_WithDecoder(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	writeFile(t, filepath.Join(dir, "lower", "config.lower"), "QUIET")
+	decode := func(data []byte, v any) error {
+		cfg, ok := v.(*appConfig)
+		if !ok {
+			return errUnsupportedType
+		}
+		cfg.Name = strings.ToLower(string(data))
+		return nil
+	}
+
+	cfg, err := NewLoader[appConfig](ProgName("lower"), WithDecoder(".lower", decode)).Get()
+	if err != nil || cfg.Name != "quiet" {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+	if _, err := NewLoader[appConfig](ProgName("lower")).Get(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("decoder leaked to another loader: %v", err)
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "name: flags\nport: 80\n")
+	l := NewLoader[appConfig](Path(path), ProgName("loadprog"))
+
+	cfg, err := l.Load(Args([]string{"-port", "8080"}))
+	if err != nil || cfg.Port != 8080 {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+	if got, _ := l.Get(); got != cfg {
+		t.Errorf("Get returned %+v, want loaded config", got)
+	}
+	if l.opts.hasArgs {
+		t.Error("per call options changed the loader")
+	}
+
+	writeFile(t, path, "name: changed\nport: 81\n")
+	cfg, err = l.Reload()
+	if err != nil || cfg.Name != "changed" || cfg.Port != 8080 {
+		t.Errorf("Reload returned %+v, %v, want the flag reapplied", cfg, err)
+	}
+}
+
+func TestLoader_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeFile(t, path, "name: before\n")
+	l := NewLoader[appConfig](Path(path), ProgName("watchpath"))
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	results := make(chan *appConfig, 1)
+	err := l.Watch(ctx, func(cfg *appConfig, err error) {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		results <- cfg
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writeFile(t, filepath.Join(filepath.Dir(path), "config.yaml"), "name: ignored\n")
+	writeFile(t, path, "name: after\n")
+	cfg := <-results
+	if cfg.Name != "after" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
+
+// countingLoader returns a loader whose decoder counts its calls and blocks the first until release is closed.
+func countingLoader(t *testing.T, decodeErr error) (*Loader[appConfig], *atomic.Int32, chan struct{}) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.count")
+	writeFile(t, path, "counted")
+	calls := &atomic.Int32{}
+	release := make(chan struct{})
+	decode := func(data []byte, v any) error {
+		cfg, ok := v.(*appConfig)
+		if !ok {
+			return errUnsupportedType
+		}
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		cfg.Name = string(data)
+		return decodeErr
+	}
+	return NewLoader[appConfig](Path(path), ProgName("countprog"), WithDecoder(".count", decode)), calls, release
+}
+
+// waitFor waits until n callers are waiting for the loader's read in progress.
+func waitFor(l *Loader[appConfig], n int) {
+	for {
+		l.mu.Lock()
+		waiters := 0
+		if l.call != nil {
+			waiters = l.call.waiters
+		}
+		l.mu.Unlock()
+		if waiters >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestLoader_SingleFlight(t *testing.T) {
+	for _, decodeErr := range []error{nil, errUnsupportedType} {
+		l, calls, release := countingLoader(t, decodeErr)
+		results := make(chan *appConfig, 10)
+		errs := make(chan error, 10)
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				cfg, err := l.Get()
+				results <- cfg
+				errs <- err
+			})
+		}
+		waitFor(l, 9)
+		close(release)
+		wg.Wait()
+		close(results)
+		close(errs)
+
+		if n := calls.Load(); n != 1 {
+			t.Errorf("decoder called %d times, want 1", n)
+		}
+		first := <-results
+		for cfg := range results {
+			if cfg != first {
+				t.Errorf("callers got different configs %p and %p", first, cfg)
+			}
+		}
+		for err := range errs {
+			if !errors.Is(err, decodeErr) {
+				t.Errorf("got error %v, want %v", err, decodeErr)
+			}
+		}
+	}
+}
+
+func TestLoader_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "name: one\n")
+	l := NewLoader[appConfig](Path(path), ProgName("reloadprog"))
+	first, err := l.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if same, err := l.Reload(); err != nil || same != first {
+		t.Errorf("unchanged reload returned %p, %v, want %p", same, err, first)
+	}
+
+	writeFile(t, path, "name: two\n")
+	second, err := l.Reload()
+	if err != nil || second.Name != "two" {
+		t.Fatalf("unexpected reload %+v, %v", second, err)
+	}
+	writeFile(t, path, "name: [bad\n")
+	if _, err := l.Reload(); err == nil {
+		t.Error("expected error for bad file")
+	}
+	if got, _ := l.Get(); got != second || first.Name != "one" {
+		t.Errorf("Get returned %+v after bad reload, first = %+v", got, first)
+	}
+
+	writeFile(t, path, "name: three\n")
+	l.Invalidate()
+	if got, err := l.Get(); err != nil || got.Name != "three" {
+		t.Errorf("Get after Invalidate returned %+v, %v", got, err)
+	}
+}
+
+func TestLoader_InvalidateDuringRead(t *testing.T) {
+	l, calls, release := countingLoader(t, nil)
+	done := make(chan *appConfig)
+	go func() {
+		cfg, _ := l.Get()
+		done <- cfg
+	}()
+	for calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	l.Invalidate()
+	close(release)
+	if cfg := <-done; cfg == nil || cfg.Name != "counted" {
+		t.Errorf("in progress read returned %+v", cfg)
+	}
+	if l.cache.Load() != nil {
+		t.Error("read in progress during Invalidate was cached")
+	}
+}
+
+func TestLoader_ReloadDuringRead(t *testing.T) {
+	l, calls, release := countingLoader(t, nil)
+	go func() { _, _ = l.Get() }()
+	for calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	writeFile(t, l.opts.path, "changed")
+	done := make(chan *appConfig)
+	go func() {
+		cfg, _ := l.Reload()
+		done <- cfg
+	}()
+	waitFor(l, 1)
+	close(release)
+	if cfg := <-done; cfg == nil || cfg.Name != "changed" {
+		t.Errorf("Reload returned %+v from a read that started before it", cfg)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("decoder called %d times, want 2", n)
+	}
+}
+
+func TestLoader_LoadDuringRead(t *testing.T) {
+	l, calls, release := countingLoader(t, nil)
+	done := make(chan *appConfig)
+	go func() {
+		cfg, _ := l.Get()
+		done <- cfg
+	}()
+	for calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	loaded, err := l.Load(Args([]string{"-port", "9"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(release)
+	<-done
+	if got, _ := l.Get(); got != loaded {
+		t.Errorf("read in progress during Load replaced its config: %+v", got)
+	}
+}
+
+func TestReload(t *testing.T) {
+	useConfigFile(t, "reloadglobal", "config.yaml", "name: before\n")
+	if cfg, err := Get[testConfig](); err != nil || cfg.Name != "before" {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+	writeFile(t, filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "reloadglobal", "config.yaml"), "name: after\n")
+	if cfg, _ := Get[testConfig](); cfg.Name != "before" {
+		t.Errorf("Get reread cached config: %+v", cfg)
+	}
+	if cfg, err := Reload[testConfig](); err != nil || cfg.Name != "after" {
+		t.Errorf("Reload returned %+v, %v", cfg, err)
+	}
+	writeFile(t, filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "reloadglobal", "config.yaml"), "name: again\n")
+	Invalidate[testConfig]()
+	if cfg, err := Get[testConfig](); err != nil || cfg.Name != "again" {
+		t.Errorf("Get after Invalidate returned %+v, %v", cfg, err)
+	}
+}
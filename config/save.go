@@ -0,0 +1,381 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	dirMode  = 0o700
+	fileMode = 0o600
+)
+
+var (
+	errNoEncoder = errors.New("saving is not supported for config format")
+	errShadowed  = errors.New("saved value is overridden by a later config file")
+)
+
+// Save writes the changes in data to the user's config file for T; see Loader.Save.
+func Save[T any](data *T) error {
+	return defaultLoader[T]().Save(data)
+}
+
+// Save writes the changes in data to the user's config file: the loader's Path, else the existing
+// $XDG_CONFIG_HOME/<program name>/config[.ext], else a new config.yaml there, creating the
+// directory if needed. The file is written atomically, by renaming a temporary file, with mode 0600.
+//
+// Only the keys whose values differ from the config as currently loaded are written, so values
+// from system wide and project files, environment variables and flags are not copied into the
+// user's file, and a changed key is left out if it matches the system wide files and defaults and
+// the user's file does not set it. Saving a key that a project file sets is an error, as the
+// change would have no effect; nothing is written.
+//
+// YAML, JSON and TOML files are supported, in the format of the existing file. When updating a
+// YAML file its comments and key order are kept and new keys are appended.
+// The cached config is invalidated, so that the next Get reads the saved file.
+func (l *Loader[T]) Save(data *T) error {
+	path, err := l.opts.saveFile()
+	if err != nil {
+		return err
+	}
+	old, err := os.ReadFile(path) //nolint:gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read config file %w", err)
+	}
+	format, err := formatOf(path, old)
+	if err != nil {
+		return err
+	}
+	base, current, above, err := l.saved(path)
+	if err != nil {
+		return err
+	}
+	values := make([]map[string]any, 0, 3) //nolint:mnd
+	for _, v := range []any{data, current.data, base} {
+		m, err := toMap(format, v)
+		if err != nil {
+			return err
+		}
+		values = append(values, m)
+	}
+	user, err := unmarshal(format, old)
+	if err != nil {
+		return fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	changed := changes(values[0], values[1], values[2], user)
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := shadowed(changed, "", current.sources, above); err != nil {
+		return err
+	}
+	out, err := encode(format, old, changed)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", path, err)
+	}
+	if err := writeAtomic(path, out); err != nil {
+		return err
+	}
+	l.Invalidate()
+	return nil
+}
+
+// saveFile returns the file that Save writes.
+func (o options) saveFile() (string, error) {
+	if o.path != "" {
+		return o.path, nil
+	}
+	dir, err := o.userDir()
+	if err != nil {
+		return "", err
+	}
+	for _, l := range o.layers() {
+		if l.dir == dir {
+			if path, ok := l.find(); ok {
+				return path, nil
+			}
+		}
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// saved returns the configs that Save compares data with: base, from the defaults and the files
+// in the layers below the user's file at path, and current, as Get reads it with the flags set by
+// Load, and the files in the layers above the user's file.
+func (l *Loader[T]) saved(path string) (*T, *state[T], []string, error) {
+	base := new(T)
+	if err := ApplyDefaults(base); err != nil {
+		return nil, nil, nil, err
+	}
+	var above []string
+	user := false
+	for _, layer := range l.opts.layers() {
+		user = user || layer.dir == filepath.Dir(path)
+		file, ok := layer.find()
+		switch {
+		case !ok || file == path:
+		case user:
+			above = append(above, file)
+		default:
+			if _, err := l.opts.decodeFile(file, base); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+	current, err := l.load(l.opts, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	l.mu.Lock()
+	flags := l.flags
+	l.mu.Unlock()
+	if err := setFlags(current.data, flags); err != nil {
+		return nil, nil, nil, err
+	}
+	return base, current, above, nil
+}
+
+// changes returns the keys of data whose values differ from current, leaving out those whose
+// values equal base unless user sets them. Nested maps are compared key by key.
+func changes(data, current, base, user map[string]any) map[string]any {
+	out := map[string]any{}
+	for k, v := range data {
+		if reflect.DeepEqual(v, current[k]) {
+			continue
+		}
+		nested, ok := v.(map[string]any)
+		if cur, isMap := current[k].(map[string]any); ok && isMap {
+			if sub := changes(nested, cur, asMap(base[k]), asMap(user[k])); len(sub) > 0 {
+				out[k] = sub
+			}
+			continue
+		}
+		if _, set := user[k]; !set && reflect.DeepEqual(v, base[k]) {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// shadowed returns an error if any key of changed, under the dotted path prefix, was supplied by one of the files above.
+func shadowed(changed map[string]any, prefix string, sources map[string]string, above []string) error {
+	for _, k := range slices.Sorted(maps.Keys(changed)) {
+		key := joinPath(prefix, k)
+		if nested, ok := changed[k].(map[string]any); ok {
+			if err := shadowed(nested, key, sources, above); err != nil {
+				return err
+			}
+			continue
+		}
+		if file := sources[key]; slices.Contains(above, file) {
+			return fmt.Errorf("%w: %s is set by %s", errShadowed, key, file)
+		}
+	}
+	return nil
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// formatOf returns the format of the config file path, whose current content is old: .yaml, .json or .toml.
+func formatOf(path string, old []byte) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" && len(old) > 0 {
+		ext = sniff(old)
+	}
+	switch ext {
+	case "", ".yaml", ".yml":
+		return ".yaml", nil
+	case ".json", ".toml":
+		return ext, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errNoEncoder, path)
+	}
+}
+
+// toMap returns v as the keys and values a config file in format holds.
+func toMap(format string, v any) (map[string]any, error) {
+	data, err := marshal(format, v)
+	if err != nil {
+		return nil, err
+	}
+	m, err := unmarshal(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal %s %w", format, err)
+	}
+	return m, nil
+}
+
+// marshal marshals v in format.
+func marshal(format string, v any) ([]byte, error) {
+	switch format {
+	case ".json":
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal json %w", err)
+		}
+		return append(out, '\n'), nil
+	case ".toml":
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+			return nil, fmt.Errorf("marshal toml %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("marshal yaml %w", err)
+		}
+		return out, nil
+	}
+}
+
+// unmarshal unmarshals data in format into a map, keeping JSON numbers exact.
+func unmarshal(format string, data []byte) (map[string]any, error) {
+	m := map[string]any{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return m, nil
+	}
+	var err error
+	switch format {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&m)
+	case ".toml":
+		err = toml.Unmarshal(data, &m)
+	default:
+		err = yaml.Unmarshal(data, &m)
+	}
+	if m == nil {
+		m = map[string]any{}
+	}
+	return m, err //nolint:wrapcheck
+}
+
+// encode merges changes into old, the current content of a config file in format.
+func encode(format string, old []byte, changes map[string]any) ([]byte, error) {
+	if format == ".yaml" {
+		return encodeYAML(old, changes)
+	}
+	m, err := unmarshal(format, old)
+	if err != nil {
+		return nil, err
+	}
+	mergeMap(m, changes)
+	return marshal(format, m)
+}
+
+// mergeMap sets the values of src in dst, merging nested maps key by key.
+func mergeMap(dst, src map[string]any) {
+	for k, v := range src {
+		if nested, ok := v.(map[string]any); ok {
+			if existing, ok := dst[k].(map[string]any); ok {
+				mergeMap(existing, nested)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// encodeYAML marshals v as YAML, merged into the document old to keep its comments and key order.
+func encodeYAML(old []byte, v any) ([]byte, error) {
+	var doc, update yaml.Node
+	if err := update.Encode(v); err != nil {
+		return nil, fmt.Errorf("marshal yaml %w", err)
+	}
+	if err := yaml.Unmarshal(old, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml %w", err)
+	}
+	root := &update
+	if len(doc.Content) > 0 {
+		mergeNode(doc.Content[0], &update)
+		root = &doc
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2) //nolint:mnd
+	if err := enc.Encode(root); err != nil {
+		return nil, fmt.Errorf("marshal yaml %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal yaml %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeNode updates dst with the values of src, keeping the comments of dst.
+// Mappings are merged key by key, appending keys missing from dst; other nodes are replaced.
+func mergeNode(dst, src *yaml.Node) {
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, value := src.Content[i], src.Content[i+1]
+			if existing := lookup(dst, key.Value); existing != nil {
+				mergeNode(existing, value)
+				continue
+			}
+			dst.Content = append(dst.Content, key, value)
+		}
+		return
+	}
+	head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+	*dst = *src
+	dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+}
+
+// lookup returns the value for key in the mapping node m, or nil.
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// writeAtomic writes data to path atomically, creating its directory if needed.
+func writeAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return fmt.Errorf("create config dir %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write config file %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("write config file %w", err)
+	}
+	if err := tmp.Chmod(fileMode); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("write config file %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("write config file %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write config file %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write config file %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package config //nolint:testpackage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type savedConfig struct {
+	Name     string   `json:"name"     toml:"name"     yaml:"name"`
+	Count    int      `json:"count"    toml:"count"    yaml:"count"`
+	Tags     []string `json:"tags"     toml:"tags"     yaml:"tags"`
+	Database struct {
+		Host string `json:"host" toml:"host" yaml:"host"`
+		Port int    `json:"port" toml:"port" yaml:"port"`
+	} `json:"database" toml:"database" yaml:"database"`
+}
+
+func TestSave_New(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Cleanup(resetCache)
+	origArgs := os.Args
+	os.Args = []string{"saveprog"}
+	t.Cleanup(func() { os.Args = origArgs })
+
+	cfg := &savedConfig{Name: "new", Count: 3, Tags: []string{"a"}}
+	cfg.Database.Host = "db"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	path := filepath.Join(xdg, "saveprog", "config.yaml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	got, err := Get[savedConfig]()
+	if err != nil || got.Name != "new" || got.Count != 3 || got.Database.Host != "db" {
+		t.Errorf("saved config read back as %+v, %v", got, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the config file, got %v", entries)
+	}
+}
+
+func TestSave_KeepsYAMLComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, `# service settings
+count: 1 # how many
+name: old
+extra: kept # not in the struct
+database:
+  # where the data lives
+  port: 5432
+  host: localhost
+tags: [x]
+`)
+	l := NewLoader[savedConfig](Path(path), ProgName("savecomments"))
+	cfg, err := l.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Name, cfg.Count, cfg.Tags = "new", 2, []string{"y", "z"}
+	cfg.Database.Host = "db"
+	if err := l.Save(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# service settings
+count: 2 # how many
+name: new
+extra: kept # not in the struct
+database:
+  # where the data lives
+  port: 5432
+  host: db
+tags:
+  - 'y'
+  - z
+`
+	if string(out) != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+	if got, _ := l.Get(); got == cfg || got.Name != "new" {
+		t.Errorf("cache not invalidated: %+v", got)
+	}
+}
+
+func TestSave_Formats(t *testing.T) {
+	tests := []struct {
+		name, fileName, content, want string
+	}{
+		{"json", "config.json", `{"name": "old"}`, `"name": "new"`},
+		{"toml", "config.toml", "name = \"old\"\n", `name = "new"`},
+		{"sniffed json", "config", `{"name": "old"}`, `"name": "new"`},
+		{"sniffed toml", "config", "name = \"old\"\n", `name = "new"`},
+		{"sniffed yaml", "config", "name: old\n", "name: new"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xdg := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdg)
+			path := filepath.Join(xdg, "saveformats", tt.fileName)
+			writeFile(t, path, tt.content)
+
+			l := NewLoader[savedConfig](ProgName("saveformats"))
+			cfg, err := l.Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg.Name = "new"
+			if err := l.Save(cfg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, _ := os.ReadFile(path)
+			if !strings.Contains(string(out), tt.want) {
+				t.Errorf("got %s, want it to contain %s", out, tt.want)
+			}
+			if got, err := l.Get(); err != nil || got.Name != "new" {
+				t.Errorf("saved config read back as %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestSave_Unsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.env")
+	writeFile(t, path, "NAME=old\n")
+	l := NewLoader[savedConfig](Path(path), ProgName("saveenv"))
+	if err := l.Save(&savedConfig{Name: "new"}); !errors.Is(err, errNoEncoder) {
+		t.Errorf("expected no encoder error, got %v", err)
+	}
+	if out, _ := os.ReadFile(path); string(out) != "NAME=old\n" {
+		t.Errorf("file changed to %q", out)
+	}
+}
+
+func TestSave_OnlyChanges(t *testing.T) {
+	files := useLayers(t, "savelayers", [5]string{"[database]\nhost = \"sys\"\nport = 1\n"})
+	user := filepath.Join(filepath.Dir(files[3]), "config.json")
+	writeFile(t, user, `{"name": "old"}`)
+	t.Setenv("SAVELAYERS_NAME", "env")
+	t.Setenv("SAVELAYERS_COUNT", "7")
+	l := NewLoader[savedConfig](ProgName("savelayers"))
+	cfg, err := l.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Database.Port = 2
+	if err := l.Save(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "{\n  \"database\": {\n    \"port\": 2\n  },\n  \"name\": \"old\"\n}\n"
+	if out, _ := os.ReadFile(user); string(out) != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+	if got, err := l.Get(); err != nil || got.Database.Port != 2 || got.Database.Host != "sys" || got.Count != 7 {
+		t.Errorf("saved config read back as %+v, %v", got, err)
+	}
+
+	writeFile(t, files[4], "database:\n  host: project\n")
+	cfg.Database.Host = "mine"
+	if err := l.Save(cfg); !errors.Is(err, errShadowed) {
+		t.Errorf("expected shadowed error, got %v", err)
+	}
+	if out, _ := os.ReadFile(user); string(out) != want {
+		t.Errorf("file changed to\n%s", out)
+	}
+}
+
+func TestSave_SkipsFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "name: old\nport: 80\n")
+	l := NewLoader[appConfig](Path(path), ProgName("saveflags"))
+	cfg, err := l.Load(Args([]string{"-port", "7000"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Name = "new"
+	if err := l.Save(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out, _ := os.ReadFile(path); string(out) != "name: new\nport: 80\n" {
+		t.Errorf("got\n%s", out)
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mattkasun/tools"
+)
+
+//nolint:gochecknoglobals
+var systemDir = "/etc" // root of the system wide config dirs
+
+// layer is a directory that may hold a config file, and the names the file may have in order of preference.
+type layer struct {
+	dir   string
+	names []string
+}
+
+// layers returns the places searched for config files, in order of increasing precedence:
+//
+//	/etc/<program name>/config[.ext]
+//	$XDG_CONFIG_DIRS/<program name>/config[.ext], from the least to the most important dir
+//	$XDG_CONFIG_HOME/<program name>/config[.ext]
+//	./.<program name>.yaml
+//
+// XDG_CONFIG_DIRS defaults to /etc/xdg. A loader with a Path has that file as its only layer.
+func (o options) layers() []layer {
+	if o.path != "" {
+		return []layer{{dir: filepath.Dir(o.path), names: []string{filepath.Base(o.path)}}}
+	}
+	prog := o.name()
+	names := []string{"config"}
+	for _, ext := range o.extensions() {
+		names = append(names, "config"+ext)
+	}
+	layers := []layer{{dir: filepath.Join(systemDir, prog), names: names}}
+	xdgDirs := filepath.SplitList(os.Getenv("XDG_CONFIG_DIRS"))
+	if len(xdgDirs) == 0 {
+		xdgDirs = []string{filepath.Join(systemDir, "xdg")}
+	}
+	for _, dir := range slices.Backward(xdgDirs) {
+		if filepath.IsAbs(dir) {
+			layers = append(layers, layer{dir: filepath.Join(dir, prog), names: names})
+		}
+	}
+	if user, err := o.userDir(); err == nil {
+		layers = append(layers, layer{dir: user, names: names})
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		layers = append(layers, layer{dir: cwd, names: []string{"." + prog + ".yaml"}})
+	}
+	return layers
+}
+
+// userDir returns the directory holding the user's config file, AppConfigDir for the default program name.
+func (o options) userDir() (string, error) {
+	if o.progName == "" {
+		dir, err := tools.AppConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("configuration dir %w", err)
+		}
+		return dir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("configuration dir %w", err)
+	}
+	return filepath.Join(dir, o.progName), nil
+}
+
+// find returns the first of the layer's files that exists.
+func (l layer) find() (string, bool) {
+	for _, name := range l.names {
+		path := filepath.Join(l.dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// files returns the config files found, in order of increasing precedence.
+func (o options) files() []string {
+	var files []string
+	for _, l := range o.layers() {
+		if path, ok := l.find(); ok {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// isFile reports whether path names a file in one of the config layers.
+func (o options) isFile(path string) bool {
+	dir, name := filepath.Split(path)
+	for _, l := range o.layers() {
+		if filepath.Clean(dir) == l.dir && slices.Contains(l.names, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// fromFiles unmarshals each config file into data in turn, so that later files deep merge over
+// earlier ones: keys missing from a file keep their values and lists are replaced.
+// It returns the file that supplied each key.
+func (o options) fromFiles(data any) (map[string]string, error) {
+	files := o.files()
+	if len(files) == 0 {
+		dirs := make([]string, 0, len(o.layers()))
+		for _, l := range o.layers() {
+			dirs = append(dirs, l.dir)
+		}
+		return nil, fmt.Errorf("read config file in %s: %w", strings.Join(dirs, ", "), os.ErrNotExist)
+	}
+	sources := make(map[string]string)
+	for _, cfgfile := range files {
+		keys, err := o.decodeFile(cfgfile, data)
+		if err != nil {
+			return nil, err
+		}
+		addSources(sources, "", keys, cfgfile)
+	}
+	return sources, nil
+}
+
+// decodeFile unmarshals the config file path into data and returns its keys, or nil if its
+// decoder cannot decode into a map.
+func (o options) decodeFile(path string, data any) (map[string]any, error) {
+	bytes, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read config file %w", err)
+	}
+	decode, err := o.decoderFor(path, bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := decode(bytes, data); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	var keys map[string]any
+	if err := decode(bytes, &keys); err != nil {
+		return nil, nil //nolint:nilerr
+	}
+	return keys, nil
+}
+
+// addSources records file as the source of each key in m, and of the keys of nested maps.
+func addSources(sources map[string]string, path string, m map[string]any, file string) {
+	for k, v := range m {
+		key := joinPath(path, k)
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			addSources(sources, key, nested, file)
+			continue
+		}
+		sources[key] = file
+	}
+}
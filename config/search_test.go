@@ -0,0 +1,115 @@
+package config //nolint:testpackage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type layeredConfig struct {
+	Name     string            `yaml:"name"`
+	Level    string            `yaml:"level"`
+	Tags     []string          `yaml:"tags"`
+	Labels   map[string]string `yaml:"labels"`
+	Database struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+		User string `yaml:"user"`
+	} `yaml:"database"`
+}
+
+// useLayers points the system, XDG and working dirs at temporary directories and returns
+// the files written for prog in /etc, the two XDG_CONFIG_DIRS, XDG_CONFIG_HOME and the working dir.
+func useLayers(t *testing.T, prog string, contents [5]string) [5]string {
+	t.Helper()
+	root := t.TempDir()
+	dirs := [5]string{"etc", "xdg1", "xdg2", "home", "project"}
+	for i := range dirs {
+		dirs[i] = filepath.Join(root, dirs[i])
+	}
+	orig := systemDir
+	systemDir = dirs[0]
+	t.Cleanup(func() { systemDir = orig })
+	t.Setenv("XDG_CONFIG_DIRS", dirs[1]+string(os.PathListSeparator)+dirs[2])
+	t.Setenv("XDG_CONFIG_HOME", dirs[3])
+	files := [5]string{
+		filepath.Join(dirs[0], prog, "config"),
+		filepath.Join(dirs[1], prog, "config.json"),
+		filepath.Join(dirs[2], prog, "config.toml"),
+		filepath.Join(dirs[3], prog, "config.yaml"),
+		filepath.Join(dirs[4], "."+prog+".yaml"),
+	}
+	for i, content := range contents {
+		if content != "" {
+			writeFile(t, files[i], content)
+		}
+	}
+	if err := os.MkdirAll(dirs[4], 0o750); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dirs[4])
+	return files
+}
+
+func TestLayers(t *testing.T) {
+	files := useLayers(t, "layered", [5]string{
+		"name: system\nlevel: warn\ntags: [a, b]\nlabels: {team: core, tier: 1}\ndatabase:\n  host: sys\n  port: 1\n  user: root\n",
+		`{"level": "info", "database": {"port": 2}}`,
+		"level = \"debug\"\n[database]\nhost = \"xdg\"\n",
+		"tags: [c]\nlabels:\n  tier: \"2\"\ndatabase:\n  user: me\n",
+		"database:\n  port: 5\n",
+	})
+	l := NewLoader[layeredConfig](ProgName("layered"))
+	cfg, err := l.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := layeredConfig{Name: "system", Level: "info", Tags: []string{"c"},
+		Labels: map[string]string{"team": "core", "tier": "2"}}
+	want.Database.Host, want.Database.Port, want.Database.User = "xdg", 5, "me"
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+
+	wantSources := map[string]string{
+		"name":          files[0],
+		"labels.team":   files[0],
+		"level":         files[1],
+		"database.host": files[2],
+		"tags":          files[3],
+		"labels.tier":   files[3],
+		"database.user": files[3],
+		"database.port": files[4],
+	}
+	if got := l.Sources(); !reflect.DeepEqual(got, wantSources) {
+		t.Errorf("sources = %v, want %v", got, wantSources)
+	}
+}
+
+func TestLayers_SystemOnly(t *testing.T) {
+	files := useLayers(t, "sysonly", [5]string{"name: system\n"})
+	t.Cleanup(resetCache)
+	origArgs := os.Args
+	os.Args = []string{"sysonly"}
+	t.Cleanup(func() { os.Args = origArgs })
+
+	cfg, err := Get[layeredConfig]()
+	if err != nil || cfg.Name != "system" {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+	if got := Sources[layeredConfig](); got["name"] != files[0] {
+		t.Errorf("sources = %v", got)
+	}
+}
+
+func TestLayers_None(t *testing.T) {
+	useLayers(t, "nolayers", [5]string{})
+	l := NewLoader[layeredConfig](ProgName("nolayers"))
+	if l.Sources() != nil {
+		t.Error("expected no sources before loading")
+	}
+	if _, err := l.Get(); err == nil {
+		t.Error("expected error when no layer has a config file")
+	}
+}
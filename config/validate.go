@@ -0,0 +1,268 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errDefault  = errors.New("invalid default")
+	errRule     = errors.New("invalid validation rule")
+	errRequired = errors.New("required")
+	errMin      = errors.New("less than minimum")
+	errMax      = errors.New("greater than maximum")
+	errOneOf    = errors.New("not one of the allowed values")
+	errPattern  = errors.New("does not match pattern")
+)
+
+// Validator is implemented by config structs that check themselves after decoding.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError records a field that failed validation.
+type FieldError struct {
+	Path  string // path of the field, eg database.port or servers[1].host
+	Value any    // the field's value
+	Err   error  // the rule that failed, or the error decoding a rule
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every violation found in a config.
+type ValidationError struct {
+	Errors []error // FieldErrors, followed by the error from a Validate hook
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the violations.
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// ApplyDefaults sets the fields of the struct pointed to by v that have a default tag, eg
+//
+//	Port int `yaml:"port" default:"8080"`
+//
+// converting the value as ApplyEnv does. Fields of nested structs are set, but nil pointers
+// to structs are left nil.
+func ApplyDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", errNotStruct, v)
+	}
+	return applyDefaults(rv.Elem(), "")
+}
+
+func applyDefaults(rv reflect.Value, path string) error {
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		fpath := joinPath(path, fieldName(field, "yaml"))
+		if value, ok := field.Tag.Lookup("default"); ok {
+			if err := setValue(fv, value); err != nil {
+				return fmt.Errorf("%w: %s=%q: %w", errDefault, fpath, value, err)
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if isStruct(fv.Type()) {
+			if err := applyDefaults(fv, fpath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the struct pointed to by v against the rules in its validate tags and then
+// calls its Validate method, if any, returning a ValidationError listing every violation.
+//
+// Rules are separated by commas:
+//
+//	required    the field is not its zero value
+//	min=N       numbers are at least N; strings, slices and maps have at least N elements
+//	max=N       numbers are at most N; strings, slices and maps have at most N elements
+//	oneof=A B C the field is one of the space separated values
+//	regex=RE    the field's text matches RE; as RE may contain commas it must be the last rule
+//
+// Bounds and values are converted to the field's type as ApplyEnv does, eg min=1s for a
+// time.Duration or max=$100 for a money.Money. Rules other than required are not checked
+// for nil pointers. Fields of nested structs, pointers to structs and slices of structs are
+// checked and reported with paths such as servers[1].host, built from yaml names.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", errNotStruct, v)
+	}
+	var errs []error
+	validate(rv.Elem(), "", &errs)
+	if hook, ok := v.(Validator); ok {
+		if err := hook.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func validate(rv reflect.Value, path string, errs *[]error) {
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		fpath := joinPath(path, fieldName(field, "yaml"))
+		if rules, ok := field.Tag.Lookup("validate"); ok {
+			for _, err := range checkRules(fv, rules) {
+				*errs = append(*errs, &FieldError{Path: fpath, Value: fv.Interface(), Err: err})
+			}
+		}
+		validateNested(fv, fpath, errs)
+	}
+}
+
+// validateNested validates structs reachable from fv.
+func validateNested(fv reflect.Value, path string, errs *[]error) {
+	switch {
+	case fv.Kind() == reflect.Pointer:
+		if !fv.IsNil() {
+			validateNested(fv.Elem(), path, errs)
+		}
+	case isStruct(fv.Type()):
+		validate(fv, path, errs)
+	case fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array:
+		for i := range fv.Len() {
+			validateNested(fv.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+// checkRules returns the rules that fv breaks.
+func checkRules(fv reflect.Value, rules string) []error {
+	var errs []error
+	for rules != "" {
+		var rule string
+		if strings.HasPrefix(rules, "regex=") {
+			rule, rules = rules, ""
+		} else {
+			rule, rules, _ = strings.Cut(rules, ",")
+		}
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "required" {
+			if fv.IsZero() {
+				errs = append(errs, errRequired)
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			continue
+		}
+		if err := checkRule(fv, name, arg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func checkRule(fv reflect.Value, name, arg string) error {
+	if fv.Kind() == reflect.Pointer {
+		fv = fv.Elem()
+	}
+	switch name {
+	case "min", "max":
+		c, err := compare(fv, arg)
+		switch {
+		case err != nil:
+			return fmt.Errorf("%w: %s=%s: %w", errRule, name, arg, err)
+		case name == "min" && c < 0:
+			return fmt.Errorf("%w %s", errMin, arg)
+		case name == "max" && c > 0:
+			return fmt.Errorf("%w %s", errMax, arg)
+		}
+	case "oneof":
+		options := strings.Fields(arg)
+		for _, option := range options {
+			want := reflect.New(fv.Type()).Elem()
+			if err := setValue(want, option); err != nil {
+				return fmt.Errorf("%w: oneof=%s: %w", errRule, arg, err)
+			}
+			if reflect.DeepEqual(fv.Interface(), want.Interface()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %s", errOneOf, strings.Join(options, ", "))
+	case "regex":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return fmt.Errorf("%w: regex=%s: %w", errRule, arg, err)
+		}
+		if !re.MatchString(formatValue(fv)) {
+			return fmt.Errorf("%w %s", errPattern, arg)
+		}
+	default:
+		return fmt.Errorf("%w: %s", errRule, name)
+	}
+	return nil
+}
+
+// compare compares fv, or its length for strings, slices and maps, with bound and returns -1, 0 or +1.
+func compare(fv reflect.Value, bound string) (int, error) {
+	switch fv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		n, err := strconv.Atoi(bound)
+		return cmp.Compare(fv.Len(), n), err //nolint:wrapcheck
+	default:
+	}
+	b := reflect.New(fv.Type()).Elem()
+	if err := setValue(b, bound); err != nil {
+		return 0, err
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(fv.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(fv.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(fv.Float(), b.Float()), nil
+	default:
+		return 0, fmt.Errorf("%w: %s", errUnsupportedType, fv.Type())
+	}
+}
+
+// joinPath appends name to a dotted field path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,160 @@
+package config //nolint:testpackage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+type server struct {
+	Host string `validate:"required"  yaml:"host"`
+	Port int    `validate:"min=1,max=65535" yaml:"port"`
+}
+
+type validatedConfig struct {
+	Name     string        `default:"svc"   validate:"required,regex=^[a-z]{2,8}$"    yaml:"name"`
+	Level    string        `default:"info"  validate:"oneof=debug info warn error"    yaml:"level"`
+	Workers  int           `default:"4"     validate:"min=1,max=64"                   yaml:"workers"`
+	Timeout  time.Duration `default:"30s"   validate:"min=1s,max=5m"                  yaml:"timeout"`
+	Budget   money.Money   `validate:"max=$1000"                                      yaml:"budget"`
+	Tags     []string      `validate:"max=3"                                          yaml:"tags"`
+	Limit    *int          `validate:"min=1"                                          yaml:"limit"`
+	Servers  []server      `validate:"min=1"                                          yaml:"servers"`
+	Database struct {
+		Host string `default:"localhost" yaml:"host"`
+		Port int    `default:"5432"      yaml:"port"`
+	} `yaml:"database"`
+	Cache *struct {
+		Size int `default:"10" yaml:"size"`
+	} `yaml:"cache"`
+}
+
+type hookConfig struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+var errMinMax = errors.New("min exceeds max")
+
+func (c *hookConfig) Validate() error {
+	if c.Min > c.Max {
+		return errMinMax
+	}
+	return nil
+}
+
+func TestApplyDefaults(t *testing.T) {
+	var cfg validatedConfig
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "svc" || cfg.Level != "info" || cfg.Workers != 4 || cfg.Timeout != 30*time.Second {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if cfg.Database.Host != "localhost" || cfg.Database.Port != 5432 || cfg.Cache != nil {
+		t.Errorf("nested defaults not applied: %+v", cfg)
+	}
+	bad := struct {
+		Port int `default:"http"`
+	}{}
+	if err := ApplyDefaults(&bad); !errors.Is(err, errDefault) {
+		t.Errorf("expected invalid default error, got %v", err)
+	}
+}
+
+func TestGet_DefaultsAndValidation(t *testing.T) {
+	useConfigFile(t, "validprog", "config.yaml", "name: web\nworkers: 8\nservers:\n  - host: a\n    port: 80\ndatabase:\n  port: 6543\n")
+	cfg, err := Get[validatedConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "web" || cfg.Workers != 8 || cfg.Level != "info" || cfg.Timeout != 30*time.Second {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if cfg.Database.Host != "localhost" || cfg.Database.Port != 6543 {
+		t.Errorf("unexpected database values: %+v", cfg.Database)
+	}
+}
+
+func TestGet_ValidationErrors(t *testing.T) {
+	useConfigFile(t, "invalidprog", "config.yaml", `name: Web-Server
+level: trace
+workers: 0
+timeout: 10m
+budget: 1500.00
+tags: [a, b, c, d]
+limit: 0
+servers:
+  - host: a
+    port: 80
+  - port: 70000
+`)
+	_, err := Get[validatedConfig]()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	want := map[string]error{
+		"name":            errPattern,
+		"level":           errOneOf,
+		"workers":         errMin,
+		"timeout":         errMax,
+		"budget":          errMax,
+		"tags":            errMax,
+		"limit":           errMin,
+		"servers[1].host": errRequired,
+		"servers[1].port": errMax,
+	}
+	if len(verr.Errors) != len(want) {
+		t.Errorf("got %d violations, want %d: %v", len(verr.Errors), len(want), err)
+	}
+	for _, e := range verr.Errors {
+		var ferr *FieldError
+		if !errors.As(e, &ferr) {
+			t.Errorf("unexpected violation %v", e)
+			continue
+		}
+		if !errors.Is(ferr, want[ferr.Path]) {
+			t.Errorf("%s: got %v, want %v", ferr.Path, ferr.Err, want[ferr.Path])
+		}
+	}
+	if !strings.Contains(err.Error(), "servers[1].port: greater than maximum 65535") {
+		t.Errorf("unexpected message %q", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(&hookConfig{Min: 1, Max: 2}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(&hookConfig{Min: 3, Max: 2}); !errors.Is(err, errMinMax) {
+		t.Errorf("expected hook error, got %v", err)
+	}
+	cfg := validatedConfig{Name: "ok", Level: "warn", Workers: 1, Timeout: time.Second, Servers: []server{{"a", 1}}}
+	if err := Validate(&cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	missing := validatedConfig{Level: "warn", Workers: 1, Timeout: time.Second}
+	if err := Validate(&missing); !errors.Is(err, errRequired) || !errors.Is(err, errMin) {
+		t.Errorf("expected required and min errors, got %v", err)
+	}
+	bad := struct {
+		Name string `validate:"unique"`
+		Port int    `validate:"min=one"`
+	}{}
+	if err := Validate(&bad); !errors.Is(err, errRule) {
+		t.Errorf("expected invalid rule error, got %v", err)
+	}
+	price := struct {
+		Price money.Money `validate:"min=50"`
+	}{Price: 4999}
+	if err := Validate(&price); !errors.Is(err, errMin) {
+		t.Errorf("expected min=50 to mean $50, got %v", err)
+	}
+	if err := Validate(hookConfig{}); !errors.Is(err, errNotStruct) {
+		t.Errorf("expected not struct error, got %v", err)
+	}
+}
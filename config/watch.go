@@ -0,0 +1,152 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+//nolint:gochecknoglobals
+var (
+	pollInterval = 2 * time.Second       // how often the config dirs are checked when inotify is unavailable
+	settleDelay  = 50 * time.Millisecond // quiet period after a file event before reloading
+)
+
+// Watch loads the configuration data for the struct type T as Get does and then watches the
+// config files for changes until ctx is done, using inotify where available and polling otherwise.
+//
+// On each change the files are decoded, environment variables applied and the result validated;
+// a good config is published atomically, so that Get returns it, and passed to fn. A bad edit is
+// passed to fn as an error and the last good config stays active. Flags set on the command line
+// by an earlier Load are set again on each reload, so they keep precedence over the files.
+func Watch[T any](ctx context.Context, fn func(cfg *T, err error)) error {
+	return defaultLoader[T]().Watch(ctx, fn)
+}
+
+// Watch loads the configuration data and reloads it when a config file changes; see Watch.
+func (l *Loader[T]) Watch(ctx context.Context, fn func(cfg *T, err error)) error {
+	if _, err := l.Get(); err != nil {
+		return err
+	}
+	changes := watch(ctx, l.opts)
+	go func() {
+		for err := range changes {
+			if err != nil {
+				fn(nil, err)
+				continue
+			}
+			l.reload(fn)
+		}
+	}()
+	return nil
+}
+
+// reload reloads the config and passes it to fn if it has changed.
+func (l *Loader[T]) reload(fn func(*T, error)) {
+	var old *T
+	if s := l.cache.Load(); s != nil {
+		old = s.data
+	}
+	data, err := l.Reload()
+	if err != nil {
+		fn(nil, fmt.Errorf("reload config: %w", err))
+		return
+	}
+	if data != old {
+		fn(data, nil)
+	}
+}
+
+// watch reports changes to config files on the returned channel, which is closed when ctx is done.
+// Watcher errors are sent as they occur; changes are sent as nil.
+// Directories are watched with inotify if possible, else all layers are polled.
+func watch(ctx context.Context, o options) <-chan error {
+	changes := make(chan error, 1)
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		go poll(ctx, o, changes)
+		return changes
+	}
+	watched := 0
+	for _, l := range o.layers() {
+		if w.Add(l.dir) == nil {
+			watched++
+		}
+	}
+	if watched == 0 {
+		_ = w.Close()
+		go poll(ctx, o, changes)
+		return changes
+	}
+	go notify(ctx, w, o, changes)
+	return changes
+}
+
+// notify forwards inotify events for config files, waiting for settleDelay so that a burst
+// of events from a single save causes one reload.
+func notify(ctx context.Context, w *fsnotify.Watcher, o options, changes chan<- error) {
+	defer close(changes)
+	defer w.Close() //nolint:errcheck
+	settle := time.NewTimer(settleDelay)
+	settle.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-w.Events:
+			if o.isFile(event.Name) && !event.Has(fsnotify.Chmod) {
+				settle.Reset(settleDelay)
+			}
+		case err := <-w.Errors:
+			send(ctx, changes, fmt.Errorf("watch config: %w", err))
+		case <-settle.C:
+			send(ctx, changes, nil)
+		}
+	}
+}
+
+// poll checks the config files every pollInterval and reports a change when their names,
+// sizes or modification times differ.
+func poll(ctx context.Context, o options, changes chan<- error) {
+	defer close(changes)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	last := snapshot(o)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if current := snapshot(o); current != last {
+				last = current
+				send(ctx, changes, nil)
+			}
+		}
+	}
+}
+
+// snapshot summarises the config files in every layer.
+func snapshot(o options) string {
+	var b strings.Builder
+	for _, l := range o.layers() {
+		for _, name := range l.names {
+			if info, err := os.Stat(filepath.Join(l.dir, name)); err == nil {
+				fmt.Fprintf(&b, "%s %d %d\n", filepath.Join(l.dir, name), info.Size(), info.ModTime().UnixNano())
+			}
+		}
+	}
+	return b.String()
+}
+
+// send sends err unless ctx is done.
+func send(ctx context.Context, changes chan<- error, err error) {
+	select {
+	case changes <- err:
+	case <-ctx.Done():
+	}
+}
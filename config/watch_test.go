@@ -0,0 +1,144 @@
+package config //nolint:testpackage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchResult struct {
+	cfg *nestedConfig
+	err error
+}
+
+func next(t *testing.T, results <-chan watchResult) watchResult {
+	t.Helper()
+	select {
+	case r := <-results:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+		return watchResult{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	useConfigFile(t, "watchprog", "config.yaml", "name: first\ncount: 1\n")
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	dir := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "watchprog")
+	path := filepath.Join(dir, "config.yaml")
+
+	results := make(chan watchResult, 4)
+	err := Watch(ctx, func(cfg *nestedConfig, err error) {
+		results <- watchResult{cfg, err}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, _ := Get[nestedConfig]()
+
+	// write in place
+	if err := os.WriteFile(path, []byte("name: second\ncount: 2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := next(t, results)
+	if r.err != nil || r.cfg.Name != "second" || r.cfg.Count != 2 {
+		t.Fatalf("unexpected reload %+v, %v", r.cfg, r.err)
+	}
+	if got, _ := Get[nestedConfig](); got != r.cfg || first.Name != "first" {
+		t.Errorf("Get returned %+v, first = %+v", got, first)
+	}
+
+	// bad edit keeps the last good config
+	if err := os.WriteFile(path, []byte("name: third\ncount: many\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if r := next(t, results); r.err == nil {
+		t.Fatalf("expected error for bad edit, got %+v", r.cfg)
+	}
+	if got, _ := Get[nestedConfig](); got.Name != "second" {
+		t.Errorf("bad edit replaced config: %+v", got)
+	}
+
+	// atomic replace by rename
+	tmp := filepath.Join(dir, "config.tmp")
+	if err := os.WriteFile(tmp, []byte("name: fourth\ncount: 4\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	r = next(t, results)
+	if r.err != nil || r.cfg.Name != "fourth" {
+		t.Fatalf("unexpected reload %+v, %v", r.cfg, r.err)
+	}
+}
+
+func TestWatch_Missing(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origArgs := os.Args
+	os.Args = []string{"nowatch"}
+	t.Cleanup(func() { os.Args = origArgs })
+	if err := Watch(t.Context(), func(*nestedConfig, error) {}); err == nil {
+		t.Error("expected error for missing config")
+	}
+}
+
+func TestPoll(t *testing.T) {
+	interval := pollInterval
+	pollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pollInterval = interval })
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Dir(dir))
+	ctx, cancel := context.WithCancel(t.Context())
+	changes := make(chan error, 1)
+	go poll(ctx, options{progName: filepath.Base(dir)}, changes)
+
+	time.Sleep(3 * pollInterval)
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("name = \"x\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other"), []byte("ignored"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-changes:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for change")
+	}
+	cancel()
+	for range changes { //nolint:revive
+	}
+}
+
+func TestIsFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/a")
+	t.Setenv("XDG_CONFIG_DIRS", "/b:relative")
+	t.Chdir(t.TempDir())
+	cwd, _ := os.Getwd()
+	for name, want := range map[string]bool{
+		"/a/prog/config":        true,
+		"/a/prog/config.yaml":   true,
+		"/a/prog/config.env":    true,
+		"/b/prog/config.json":   true,
+		"/etc/prog/config.toml": true,
+		"/etc/xdg/prog/config":  false,
+		"relative/prog/config":  false,
+		cwd + "/.prog.yaml":     true,
+		cwd + "/config.yaml":    false,
+		"/a/prog/config.tmp":    false,
+		"/a/prog/other.yaml":    false,
+		"/a/prog/config.yaml~":  false,
+		"/a/other/config.yaml":  false,
+	} {
+		if got := (options{progName: "prog"}).isFile(name); got != want {
+			t.Errorf("isFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -3,7 +3,9 @@ module github.com/mattkasun/tools
 go 1.25.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/Kairum-Labs/should v0.2.3
+	github.com/fsnotify/fsnotify v1.10.1
 	go.yaml.in/yaml/v4 v4.0.0-rc.6
 	golang.org/x/term v0.44.0
 )
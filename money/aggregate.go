@@ -0,0 +1,124 @@
+package money
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math/big"
+	"slices"
+)
+
+// ErrEmpty is returned when aggregating no values.
+var ErrEmpty = errors.New("no values")
+
+// List is a slice of Money with aggregate and sorting helpers.
+type List []Money
+
+// All returns an iterator over the values in l.
+func (l List) All() iter.Seq[Money] {
+	return slices.Values(l)
+}
+
+// Sum returns the total of l; see Sum.
+func (l List) Sum() (Money, error) {
+	return Sum(l.All())
+}
+
+// Sort sorts l in ascending order.
+func (l List) Sort() {
+	slices.Sort(l)
+}
+
+// Sorted returns a sorted copy of l.
+func (l List) Sorted() List {
+	return slices.Sorted(l.All())
+}
+
+// Sum returns the total of seq, or an OverflowError as soon as a running total exceeds the ceiling.
+func Sum(seq iter.Seq[Money]) (Money, error) {
+	var total Money
+	for m := range seq {
+		var err error
+		if total, err = total.Add(m); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// Min returns the smallest value in seq.
+func Min(seq iter.Seq[Money]) (Money, error) {
+	return extreme(seq, func(a, b Money) bool { return a < b })
+}
+
+// Max returns the largest value in seq.
+func Max(seq iter.Seq[Money]) (Money, error) {
+	return extreme(seq, func(a, b Money) bool { return a > b })
+}
+
+// Mean returns the average of seq rounded with the optional mode, which defaults to RoundHalfUp.
+// The total is accumulated exactly, so the mean of values near the ceiling does not overflow.
+func Mean(seq iter.Seq[Money], mode ...Rounding) (Money, error) {
+	total := new(big.Int)
+	var n int64
+	for m := range seq {
+		total.Add(total, big.NewInt(int64(m)))
+		n++
+	}
+	if n == 0 {
+		return 0, ErrEmpty
+	}
+	return checked("mean", total, n, roundingMode(mode).round(new(big.Rat).SetFrac(total, big.NewInt(n))))
+}
+
+// Median returns the middle value of seq; for an even number of values it is the mean of the
+// two middle values rounded with the optional mode, which defaults to RoundHalfUp.
+func Median(seq iter.Seq[Money], mode ...Rounding) (Money, error) {
+	sorted := slices.Sorted(seq)
+	n := len(sorted)
+	switch {
+	case n == 0:
+		return 0, ErrEmpty
+	case n%2 == 1:
+		return sorted[n/2], nil
+	default:
+		return Mean(slices.Values(sorted[n/2-1:n/2+1]), mode...)
+	}
+}
+
+// SortBy sorts items in ascending order of value, keeping the original order of equal values.
+func SortBy[T any](items []T, value func(T) Money) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		return cmp.Compare(value(a), value(b))
+	})
+}
+
+// GroupBy totals the value of items by key, eg the spend for each category.
+func GroupBy[T any, K comparable](items iter.Seq[T], key func(T) K, value func(T) Money) (map[K]Money, error) {
+	totals := map[K]Money{}
+	for item := range items {
+		k := key(item)
+		total, err := totals[k].Add(value(item))
+		if err != nil {
+			return nil, fmt.Errorf("group %v: %w", k, err)
+		}
+		totals[k] = total
+	}
+	return totals, nil
+}
+
+func extreme(seq iter.Seq[Money], better func(a, b Money) bool) (Money, error) {
+	var result Money
+	found := false
+	for m := range seq {
+		if !found || better(m, result) {
+			result = m
+			found = true
+		}
+	}
+	if !found {
+		return 0, ErrEmpty
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package money_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestAggregates(t *testing.T) {
+	values := money.List{500, -200, 1200, 300}
+	total, err := values.Sum()
+	should.NotBeError(t, err)
+	should.BeEqual(t, total, money.Money(1800))
+	least, err := money.Min(values.All())
+	should.NotBeError(t, err)
+	should.BeEqual(t, least, money.Money(-200))
+	most, err := money.Max(values.All())
+	should.NotBeError(t, err)
+	should.BeEqual(t, most, money.Money(1200))
+	mean, err := money.Mean(values.All())
+	should.NotBeError(t, err)
+	should.BeEqual(t, mean, money.Money(450))
+	median, err := money.Median(values.All())
+	should.NotBeError(t, err)
+	should.BeEqual(t, median, money.Money(400))
+	median, err = money.Median(slices.Values([]money.Money{1, 2, 3, 4}), money.RoundHalfEven)
+	should.NotBeError(t, err)
+	should.BeEqual(t, median, money.Money(2))
+	median, err = money.Median(slices.Values([]money.Money{9, 1, 5}))
+	should.NotBeError(t, err)
+	should.BeEqual(t, median, money.Money(5))
+}
+
+func TestAggregateErrors(t *testing.T) {
+	empty := money.List{}
+	total, err := empty.Sum()
+	should.NotBeError(t, err)
+	should.BeEqual(t, total, money.Money(0))
+	_, err = money.Min(empty.All())
+	should.BeErrorIs(t, err, money.ErrEmpty)
+	_, err = money.Max(empty.All())
+	should.BeErrorIs(t, err, money.ErrEmpty)
+	_, err = money.Mean(empty.All())
+	should.BeErrorIs(t, err, money.ErrEmpty)
+	_, err = money.Median(empty.All())
+	should.BeErrorIs(t, err, money.ErrEmpty)
+
+	big := money.List{1e16, 1e16}
+	_, err = big.Sum()
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	mean, err := money.Mean(big.All())
+	should.NotBeError(t, err)
+	should.BeEqual(t, mean, money.Money(1e16))
+}
+
+func TestSortAndGroup(t *testing.T) {
+	values := money.List{3, 1, 2}
+	should.BeEqual(t, values.Sorted(), money.List{1, 2, 3})
+	should.BeEqual(t, values, money.List{3, 1, 2})
+	values.Sort()
+	should.BeEqual(t, values, money.List{1, 2, 3})
+
+	type expense struct {
+		category string
+		amount   money.Money
+	}
+	expenses := []expense{{"rent", 1000}, {"food", 200}, {"fuel", 200}, {"food", 50}}
+	money.SortBy(expenses, func(e expense) money.Money { return e.amount })
+	should.BeEqual(t, expenses, []expense{{"food", 50}, {"food", 200}, {"fuel", 200}, {"rent", 1000}})
+	totals, err := money.GroupBy(slices.Values(expenses),
+		func(e expense) string { return e.category },
+		func(e expense) money.Money { return e.amount })
+	should.NotBeError(t, err)
+	should.BeEqual(t, totals, map[string]money.Money{"rent": 1000, "food": 250, "fuel": 200})
+}
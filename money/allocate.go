@@ -0,0 +1,111 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	// ErrInvalidSplit is returned when splitting into fewer than one part.
+	ErrInvalidSplit = errors.New("invalid number of parts")
+	// ErrInvalidRatio is returned when allocation ratios are missing, negative or sum to zero.
+	ErrInvalidRatio = errors.New("invalid ratio")
+)
+
+// Split divides m into n parts that differ by at most one cent and always sum to m.
+// Remainder cents go to the earliest parts.
+func (m Money) Split(n int) ([]Money, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSplit, n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Allocate divides m in proportion to ratios, eg Allocate(30, 30, 40), so that the parts always sum to m.
+// Each part receives the floor of its share; the remaining cents go one each to the parts
+// with the largest fractional share, earliest first on a tie.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	parts, err := allocate(big.NewInt(int64(m)), ratios)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Money, len(parts))
+	for i, p := range parts {
+		result[i] = Money(p.Int64())
+	}
+	return result, nil
+}
+
+// Split divides a into n parts; see Money.Split.
+func (a Amount) Split(n int) ([]Amount, error) {
+	parts, err := Money(a.minor).Split(n)
+	if err != nil {
+		return nil, err
+	}
+	return a.amounts(parts), nil
+}
+
+// Allocate divides a in proportion to ratios; see Money.Allocate.
+func (a Amount) Allocate(ratios ...int64) ([]Amount, error) {
+	parts, err := Money(a.minor).Allocate(ratios...)
+	if err != nil {
+		return nil, err
+	}
+	return a.amounts(parts), nil
+}
+
+func (a Amount) amounts(parts []Money) []Amount {
+	result := make([]Amount, len(parts))
+	for i, p := range parts {
+		result[i] = Amount{minor: int64(p), currency: a.currency}
+	}
+	return result
+}
+
+// allocate implements the largest remainder method on the absolute value of total.
+func allocate(total *big.Int, ratios []int64) ([]*big.Int, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("%w: no ratios", ErrInvalidRatio)
+	}
+	sum := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("%w: negative ratio %d", ErrInvalidRatio, r)
+		}
+		sum.Add(sum, big.NewInt(r))
+	}
+	if sum.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ratios sum to zero", ErrInvalidRatio)
+	}
+	abs := new(big.Int).Abs(total)
+	parts := make([]*big.Int, len(ratios))
+	rems := make([]*big.Int, len(ratios))
+	left := new(big.Int).Set(abs)
+	for i, r := range ratios {
+		parts[i], rems[i] = new(big.Int).QuoRem(new(big.Int).Mul(abs, big.NewInt(r)), sum, new(big.Int))
+		left.Sub(left, parts[i])
+	}
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rems[order[i]].Cmp(rems[order[j]]) > 0
+	})
+	// left is less than the number of parts
+	for i := range left.Int64() {
+		parts[order[i]].Add(parts[order[i]], big.NewInt(1))
+	}
+	if total.Sign() < 0 {
+		for _, p := range parts {
+			p.Neg(p)
+		}
+	}
+	return parts, nil
+}
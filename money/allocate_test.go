@@ -0,0 +1,60 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func sum(parts []money.Money) money.Money {
+	var total money.Money
+	for _, p := range parts {
+		total += p
+	}
+	return total
+}
+
+func TestSplit(t *testing.T) {
+	parts, err := money.Money(1000).Split(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{334, 333, 333})
+	parts, err = money.Money(-1000).Split(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{-334, -333, -333})
+	should.BeEqual(t, sum(parts), money.Money(-1000))
+	parts, err = money.Money(2).Split(5)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{1, 1, 0, 0, 0})
+	_, err = money.Money(100).Split(0)
+	should.BeErrorIs(t, err, money.ErrInvalidSplit)
+}
+
+func TestAllocate(t *testing.T) {
+	parts, err := money.Money(10001).Allocate(30, 30, 40)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{3000, 3000, 4001})
+	parts, err = money.Money(5).Allocate(70, 30)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{4, 1})
+	parts, err = money.Money(1e16).Allocate(1, 1, 1)
+	should.NotBeError(t, err)
+	should.BeEqual(t, sum(parts), money.Money(1e16))
+	parts, err = money.Money(100).Allocate(0, 1)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts, []money.Money{0, 100})
+	_, err = money.Money(100).Allocate()
+	should.BeErrorIs(t, err, money.ErrInvalidRatio)
+	_, err = money.Money(100).Allocate(1, -1)
+	should.BeErrorIs(t, err, money.ErrInvalidRatio)
+	_, err = money.Money(100).Allocate(0, 0)
+	should.BeErrorIs(t, err, money.ErrInvalidRatio)
+}
+
+func TestAmountSplit(t *testing.T) {
+	jpy, _ := money.NewAmount(100, "JPY")
+	parts, err := jpy.Split(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts[0].Minor(), int64(34))
+	should.BeEqual(t, parts[2].Currency().Code, "JPY")
+}
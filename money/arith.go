@@ -0,0 +1,76 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrOverflow is returned when a result exceeds the one hundred trillion ceiling.
+	ErrOverflow = errors.New("overflow")
+	// ErrDivideByZero is returned when dividing by zero.
+	ErrDivideByZero = errors.New("division by zero")
+
+	bigMax = big.NewInt(maxValue) //nolint:gochecknoglobals
+)
+
+// OverflowError records an operation whose result exceeds the one hundred trillion ceiling.
+type OverflowError struct {
+	Op string // operator, eg + or *
+	A  any    // first operand
+	B  any    // second operand
+}
+
+// Error implements the error interface.
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%s: %v %s %v exceeds ±%d minor units", ErrOverflow, e.A, e.Op, e.B, int64(maxValue))
+}
+
+// Unwrap returns ErrOverflow so that errors.Is(err, ErrOverflow) reports true.
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// Add returns m + n or an OverflowError.
+func (m Money) Add(n Money) (Money, error) {
+	r := new(big.Int).Add(big.NewInt(int64(m)), big.NewInt(int64(n)))
+	return checked("+", m, n, r)
+}
+
+// Sub returns m - n or an OverflowError.
+func (m Money) Sub(n Money) (Money, error) {
+	r := new(big.Int).Sub(big.NewInt(int64(m)), big.NewInt(int64(n)))
+	return checked("-", m, n, r)
+}
+
+// MulInt returns m * n or an OverflowError.
+func (m Money) MulInt(n int64) (Money, error) {
+	r := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(n))
+	return checked("*", m, n, r)
+}
+
+// MulRat returns m * r rounded to the nearest cent, or an OverflowError.
+// The optional mode defaults to RoundHalfUp.
+func (m Money) MulRat(r *big.Rat, mode ...Rounding) (Money, error) {
+	p := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), r)
+	return checked("*", m, r.RatString(), roundingMode(mode).round(p))
+}
+
+// Div returns m / n rounded to the nearest cent.
+// The optional mode defaults to RoundHalfUp.
+func (m Money) Div(n int64, mode ...Rounding) (Money, error) {
+	if n == 0 {
+		return 0, fmt.Errorf("%w: %v / 0", ErrDivideByZero, m)
+	}
+	q := new(big.Rat).SetFrac(big.NewInt(int64(m)), big.NewInt(n))
+	return checked("/", m, n, roundingMode(mode).round(q))
+}
+
+// checked returns r as Money if it lies within the ceiling.
+func checked(op string, a, b any, r *big.Int) (Money, error) {
+	if r.CmpAbs(bigMax) > 0 {
+		return 0, &OverflowError{Op: op, A: a, B: b}
+	}
+	return Money(r.Int64()), nil
+}
@@ -0,0 +1,70 @@
+package money_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestAdd(t *testing.T) {
+	sum, err := money.Money(150).Add(money.Money(-50))
+	should.NotBeError(t, err)
+	should.BeEqual(t, sum, money.Money(100))
+	_, err = money.Money(1e16).Add(money.Money(1))
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	var overflow *money.OverflowError
+	should.BeErrorAs(t, err, &overflow)
+	should.BeEqual(t, overflow.Op, "+")
+	_, err = money.Money(math.MaxInt64).Add(money.Money(1))
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestSub(t *testing.T) {
+	diff, err := money.Money(100).Sub(money.Money(250))
+	should.NotBeError(t, err)
+	should.BeEqual(t, diff, money.Money(-150))
+	_, err = money.Money(-1e16).Sub(money.Money(1))
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestMulInt(t *testing.T) {
+	prod, err := money.Money(199).MulInt(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, prod, money.Money(597))
+	_, err = money.Money(1e15).MulInt(11)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	_, err = money.Money(math.MaxInt64).MulInt(2)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestMulRat(t *testing.T) {
+	prod, err := money.Money(999).MulRat(big.NewRat(1, 20))
+	should.NotBeError(t, err)
+	should.BeEqual(t, prod, money.Money(50))
+	prod, err = money.Money(-999).MulRat(big.NewRat(1, 20))
+	should.NotBeError(t, err)
+	should.BeEqual(t, prod, money.Money(-50))
+	_, err = money.Money(1e16).MulRat(big.NewRat(3, 2))
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestDiv(t *testing.T) {
+	q, err := money.Money(1000).Div(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, q, money.Money(333))
+	q, err = money.Money(-5).Div(2)
+	should.NotBeError(t, err)
+	should.BeEqual(t, q, money.Money(-3))
+	_, err = money.Money(1000).Div(0)
+	should.BeErrorIs(t, err, money.ErrDivideByZero)
+}
+
+func TestAmountOverflow(t *testing.T) {
+	a, _ := money.NewAmount(1e16, "JPY")
+	b, _ := money.NewAmount(1, "JPY")
+	_, err := a.Add(b)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
@@ -0,0 +1,183 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var bigPattern = regexp.MustCompile(`^[+-]?(\d+)(\.(\d*))?$`) //nolint:gochecknoglobals
+
+// BigMoney is an arbitrary precision monetary value with a fixed number of decimal places,
+// for amounts beyond the one hundred trillion ceiling of Money or with more than two decimal
+// places, eg 18 for crypto currencies. The zero value is zero with no decimal places.
+type BigMoney struct {
+	units *big.Int // value * 10^scale
+	scale int
+}
+
+// NewBig returns a BigMoney of units at scale decimal places, eg NewBig(big.NewInt(1234), 2) is 12.34.
+func NewBig(units *big.Int, scale int) BigMoney {
+	return BigMoney{units: new(big.Int).Set(units), scale: max(scale, 0)}
+}
+
+// ParseBig parses a plain decimal string such as -1234.567 at scale decimal places.
+func ParseBig(s string, scale int) (BigMoney, error) {
+	match := bigPattern.FindStringSubmatch(s)
+	if match == nil {
+		return BigMoney{}, &ParseError{Input: s, Offset: 0, Err: ErrSyntax, Detail: "not a decimal number"}
+	}
+	frac := match[3]
+	if len(frac) > scale {
+		return BigMoney{}, &ParseError{Input: s, Offset: len(s) - len(frac) + scale, Err: ErrPrecision, Detail: frac}
+	}
+	units, _ := new(big.Int).SetString(match[1]+frac+strings.Repeat("0", scale-len(frac)), 10) //nolint:mnd
+	if s[0] == '-' {
+		units.Neg(units)
+	}
+	return BigMoney{units: units, scale: scale}, nil
+}
+
+// Big returns m as a BigMoney with two decimal places.
+func (m Money) Big() BigMoney {
+	return BigMoney{units: big.NewInt(int64(m)), scale: centsExponent}
+}
+
+// Money returns b as Money if it has no more than two significant decimal places and lies within the ceiling.
+func (b BigMoney) Money() (Money, error) {
+	cents := b.Rescale(centsExponent, RoundTruncate)
+	if !cents.Equal(b) {
+		return 0, fmt.Errorf("%w: %s", ErrPrecision, b.Decimal())
+	}
+	return checked("to", b.Decimal(), "Money", cents.units)
+}
+
+// Units returns the value in units of the smallest decimal place.
+func (b BigMoney) Units() *big.Int {
+	return new(big.Int).Set(b.int())
+}
+
+// Scale returns the number of decimal places.
+func (b BigMoney) Scale() int {
+	return b.scale
+}
+
+// Rescale returns b with scale decimal places, rounding with the optional mode which defaults to RoundHalfUp.
+func (b BigMoney) Rescale(scale int, mode ...Rounding) BigMoney {
+	scale = max(scale, 0)
+	r := new(big.Rat).SetFrac(b.int(), pow10(b.scale))
+	r.Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	return BigMoney{units: roundingMode(mode).round(r), scale: scale}
+}
+
+// String implements the stringer interface for BigMoney, eg -1,234.567.
+func (b BigMoney) String() string {
+	return Formatter{Locale: Locale{Symbol: "", Code: "", Decimal: ".", Group: ","}}.FormatBig(b)
+}
+
+// Decimal returns b as a plain decimal string without grouping, eg -1234.567.
+func (b BigMoney) Decimal() string {
+	return Formatter{}.FormatBig(b)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of b.
+func (b BigMoney) Sign() int {
+	return b.int().Sign()
+}
+
+// IsZero reports whether b is zero.
+func (b BigMoney) IsZero() bool {
+	return b.Sign() == 0
+}
+
+// Cmp compares b and c and returns -1, 0 or +1.
+func (b BigMoney) Cmp(c BigMoney) int {
+	x, y := align(b, c)
+	return x.units.Cmp(y.units)
+}
+
+// Equal reports whether b and c have the same value, regardless of scale.
+func (b BigMoney) Equal(c BigMoney) bool {
+	return b.Cmp(c) == 0
+}
+
+// Neg returns -b.
+func (b BigMoney) Neg() BigMoney {
+	return BigMoney{units: new(big.Int).Neg(b.int()), scale: b.scale}
+}
+
+// Abs returns the absolute value of b.
+func (b BigMoney) Abs() BigMoney {
+	return BigMoney{units: new(big.Int).Abs(b.int()), scale: b.scale}
+}
+
+// Add returns b + c at the larger of their scales.
+func (b BigMoney) Add(c BigMoney) BigMoney {
+	x, y := align(b, c)
+	return BigMoney{units: x.units.Add(x.units, y.units), scale: x.scale}
+}
+
+// Sub returns b - c at the larger of their scales.
+func (b BigMoney) Sub(c BigMoney) BigMoney {
+	x, y := align(b, c)
+	return BigMoney{units: x.units.Sub(x.units, y.units), scale: x.scale}
+}
+
+// MulInt returns b * n.
+func (b BigMoney) MulInt(n int64) BigMoney {
+	return BigMoney{units: new(big.Int).Mul(b.int(), big.NewInt(n)), scale: b.scale}
+}
+
+// MulRat returns b * r rounded to the scale of b with the optional mode, which defaults to RoundHalfUp.
+func (b BigMoney) MulRat(r *big.Rat, mode ...Rounding) BigMoney {
+	p := new(big.Rat).Mul(new(big.Rat).SetInt(b.int()), r)
+	return BigMoney{units: roundingMode(mode).round(p), scale: b.scale}
+}
+
+// Div returns b / n rounded to the scale of b with the optional mode, which defaults to RoundHalfUp.
+func (b BigMoney) Div(n int64, mode ...Rounding) (BigMoney, error) {
+	if n == 0 {
+		return BigMoney{}, fmt.Errorf("%w: %v / 0", ErrDivideByZero, b)
+	}
+	return b.MulRat(big.NewRat(1, n), mode...), nil
+}
+
+// Split divides b into n parts that always sum to b; see Money.Split.
+func (b BigMoney) Split(n int) ([]BigMoney, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSplit, n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return b.Allocate(ratios...)
+}
+
+// Allocate divides b in proportion to ratios so that the parts always sum to b; see Money.Allocate.
+func (b BigMoney) Allocate(ratios ...int64) ([]BigMoney, error) {
+	parts, err := allocate(b.int(), ratios)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]BigMoney, len(parts))
+	for i, p := range parts {
+		result[i] = BigMoney{units: p, scale: b.scale}
+	}
+	return result, nil
+}
+
+// int returns the units of b, treating the zero value as zero.
+func (b BigMoney) int() *big.Int {
+	if b.units == nil {
+		return new(big.Int)
+	}
+	return b.units
+}
+
+// align returns copies of b and c at the larger of their scales.
+func align(b, c BigMoney) (BigMoney, BigMoney) {
+	scale := max(b.scale, c.scale)
+	return b.Rescale(scale), c.Rescale(scale)
+}
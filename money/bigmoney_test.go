@@ -0,0 +1,89 @@
+package money_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func mustBig(t *testing.T, s string, scale int) money.BigMoney {
+	t.Helper()
+	b, err := money.ParseBig(s, scale)
+	should.NotBeError(t, err)
+	return b
+}
+
+func TestParseBig(t *testing.T) {
+	b := mustBig(t, "-123456789012345678901.5", 18)
+	should.BeEqual(t, b.Scale(), 18)
+	should.BeEqual(t, b.Decimal(), "-123456789012345678901.500000000000000000")
+	should.BeEqual(t, mustBig(t, "1234567.891", 3).String(), "1,234,567.891")
+	_, err := money.ParseBig("1.234", 2)
+	should.BeErrorIs(t, err, money.ErrPrecision)
+	_, err = money.ParseBig("1,000", 2)
+	should.BeErrorIs(t, err, money.ErrSyntax)
+	should.BeEqual(t, money.BigMoney{}.String(), "0")
+}
+
+func TestBigMoneyConversion(t *testing.T) {
+	b := money.Money(-123456).Big()
+	should.BeEqual(t, b.Decimal(), "-1234.56")
+	m, err := b.Money()
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(-123456))
+	m, err = mustBig(t, "12.340000", 6).Money()
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(1234))
+	_, err = mustBig(t, "12.345", 3).Money()
+	should.BeErrorIs(t, err, money.ErrPrecision)
+	_, err = mustBig(t, "100000000000000.01", 2).Money()
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestBigMoneyArithmetic(t *testing.T) {
+	huge := mustBig(t, "90000000000000000000", 2)
+	sum := huge.Add(mustBig(t, "0.001", 3))
+	should.BeEqual(t, sum.Decimal(), "90000000000000000000.001")
+	should.BeEqual(t, sum.Sub(huge).Decimal(), "0.001")
+	should.BeEqual(t, huge.MulInt(3).Decimal(), "270000000000000000000.00")
+	should.BeEqual(t, mustBig(t, "2.50", 2).Tax(5*money.Percent).Decimal(), "0.13")
+	should.BeEqual(t, mustBig(t, "2.50", 2).Tax(5*money.Percent, money.RoundHalfEven).Decimal(), "0.12")
+	should.BeEqual(t, mustBig(t, "100", 2).WithTax(15*money.Percent).Decimal(), "115.00")
+	q, err := mustBig(t, "1", 18).Div(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, q.Decimal(), "0.333333333333333333")
+	_, err = huge.Div(0)
+	should.BeErrorIs(t, err, money.ErrDivideByZero)
+	should.BeEqual(t, mustBig(t, "1.005", 3).Rescale(2, money.RoundHalfEven).Decimal(), "1.00")
+	should.BeEqual(t, mustBig(t, "-1", 0).Abs().Decimal(), "1")
+}
+
+func TestBigMoneyComparison(t *testing.T) {
+	should.BeTrue(t, mustBig(t, "1.5", 1).Equal(mustBig(t, "1.50", 2)))
+	should.BeEqual(t, mustBig(t, "1.5", 1).Cmp(mustBig(t, "1.51", 2)), -1)
+	should.BeEqual(t, mustBig(t, "-1", 0).Neg().Cmp(money.BigMoney{}), 1)
+	should.BeTrue(t, money.NewBig(big.NewInt(0), 2).IsZero())
+}
+
+func TestBigMoneyAllocate(t *testing.T) {
+	parts, err := mustBig(t, "0.000000000000000010", 18).Split(3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, parts[0].Units().Int64(), int64(4))
+	should.BeEqual(t, parts[2].Units().Int64(), int64(3))
+	parts, err = mustBig(t, "-100000000000000000000.01", 2).Allocate(30, 30, 40)
+	should.NotBeError(t, err)
+	total := money.BigMoney{}
+	for _, p := range parts {
+		total = total.Add(p)
+	}
+	should.BeEqual(t, total.Decimal(), "-100000000000000000000.01")
+	_, err = parts[0].Split(0)
+	should.BeErrorIs(t, err, money.ErrInvalidSplit)
+}
+
+func TestFormatBig(t *testing.T) {
+	f := money.Formatter{Locale: money.LocaleDE, Accounting: true}
+	should.BeEqual(t, f.FormatBig(mustBig(t, "-1234567.891", 3)), "(1.234.567,891 €)")
+}
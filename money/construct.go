@@ -0,0 +1,54 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// ErrInvalidMinor is returned when minor units are out of range for FromMajorMinor.
+var ErrInvalidMinor = errors.New("invalid minor units")
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`) //nolint:gochecknoglobals
+
+// FromCents returns a Money of cents; max value is one hundred trillion.
+func FromCents(cents int64) (Money, error) {
+	return checked("from", cents, "cents", big.NewInt(cents))
+}
+
+// FromMajorMinor returns a Money from whole dollars and cents, eg FromMajorMinor(-5, 25) is $-5.25.
+// minor must be between 0 and 99, or between -99 and 0 when major is zero.
+func FromMajorMinor(major, minor int64) (Money, error) {
+	switch {
+	case minor <= -dollarInCents || minor >= dollarInCents:
+		return 0, fmt.Errorf("%w: %d", ErrInvalidMinor, minor)
+	case minor < 0 && major != 0:
+		return 0, fmt.Errorf("%w: negative minor %d with major %d", ErrInvalidMinor, minor, major)
+	}
+	cents := new(big.Int).Mul(big.NewInt(major), big.NewInt(dollarInCents))
+	if major < 0 {
+		cents.Sub(cents, big.NewInt(minor))
+	} else {
+		cents.Add(cents, big.NewInt(minor))
+	}
+	return checked("from", major, minor, cents)
+}
+
+// FromString returns a Money from a plain decimal string such as 1.005 or -42.
+// The conversion is exact; values with more than two decimal places are rounded with the optional mode,
+// which defaults to RoundHalfUp like New.
+func FromString(s string, mode ...Rounding) (Money, error) {
+	if !decimalPattern.MatchString(s) {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrSyntax, Detail: "not a decimal number"}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrSyntax, Detail: "not a decimal number"}
+	}
+	cents := roundingMode(mode).round(r.Mul(r, big.NewRat(dollarInCents, 1)))
+	if cents.CmpAbs(bigMax) > 0 {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrOverflow, Detail: "exceeds ceiling"}
+	}
+	return Money(cents.Int64()), nil
+}
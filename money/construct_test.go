@@ -0,0 +1,59 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestFromCents(t *testing.T) {
+	m, err := money.FromCents(-12345)
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(-12345))
+	_, err = money.FromCents(1e16 + 1)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestFromMajorMinor(t *testing.T) {
+	m, err := money.FromMajorMinor(12, 34)
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(1234))
+	m, err = money.FromMajorMinor(-5, 25)
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(-525))
+	m, err = money.FromMajorMinor(0, -25)
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(-25))
+	_, err = money.FromMajorMinor(1, 100)
+	should.BeErrorIs(t, err, money.ErrInvalidMinor)
+	_, err = money.FromMajorMinor(1, -1)
+	should.BeErrorIs(t, err, money.ErrInvalidMinor)
+	_, err = money.FromMajorMinor(1e14, 1)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestFromString(t *testing.T) {
+	valid := map[string]money.Money{
+		"1.005":              101,
+		"-1.005":             -101,
+		"10.125":             1013,
+		"0.004":              0,
+		"+.5":                50,
+		"42":                 4200,
+		"42.":                4200,
+		"99999999999999.99":  9999999999999999,
+		"100000000000000.00": 1e16,
+	}
+	for input, want := range valid {
+		got, err := money.FromString(input)
+		should.NotBeError(t, err, should.WithMessage(input))
+		should.BeEqual(t, got, want, should.WithMessage(input))
+	}
+	for _, input := range []string{"", "1e3", "1/3", "$5", "1,000", ".", "--1"} {
+		_, err := money.FromString(input)
+		should.BeErrorIs(t, err, money.ErrSyntax, should.WithMessage(input))
+	}
+	_, err := money.FromString("100000000000000.01")
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
@@ -0,0 +1,185 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrUnknownCurrency is returned when a currency code is not in the ISO 4217 table.
+	ErrUnknownCurrency = errors.New("unknown currency")
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Currency holds the ISO 4217 metadata for a currency.
+type Currency struct {
+	Code     string // alphabetic code, eg EUR
+	Numeric  string // numeric code, eg 978
+	Exponent int    // number of minor units, eg 2 for EUR, 0 for JPY, 3 for KWD
+	Symbol   string
+	Name     string
+}
+
+//nolint:gochecknoglobals,mnd
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Numeric: "784", Exponent: 2, Symbol: "د.إ", Name: "UAE Dirham"},
+	"AUD": {Code: "AUD", Numeric: "036", Exponent: 2, Symbol: "A$", Name: "Australian Dollar"},
+	"BHD": {Code: "BHD", Numeric: "048", Exponent: 3, Symbol: "BD", Name: "Bahraini Dinar"},
+	"BRL": {Code: "BRL", Numeric: "986", Exponent: 2, Symbol: "R$", Name: "Brazilian Real"},
+	"CAD": {Code: "CAD", Numeric: "124", Exponent: 2, Symbol: "CA$", Name: "Canadian Dollar"},
+	"CHF": {Code: "CHF", Numeric: "756", Exponent: 2, Symbol: "CHF", Name: "Swiss Franc"},
+	"CLP": {Code: "CLP", Numeric: "152", Exponent: 0, Symbol: "CLP$", Name: "Chilean Peso"},
+	"CNY": {Code: "CNY", Numeric: "156", Exponent: 2, Symbol: "CN¥", Name: "Yuan Renminbi"},
+	"CZK": {Code: "CZK", Numeric: "203", Exponent: 2, Symbol: "Kč", Name: "Czech Koruna"},
+	"DKK": {Code: "DKK", Numeric: "208", Exponent: 2, Symbol: "kr", Name: "Danish Krone"},
+	"EUR": {Code: "EUR", Numeric: "978", Exponent: 2, Symbol: "€", Name: "Euro"},
+	"GBP": {Code: "GBP", Numeric: "826", Exponent: 2, Symbol: "£", Name: "Pound Sterling"},
+	"HKD": {Code: "HKD", Numeric: "344", Exponent: 2, Symbol: "HK$", Name: "Hong Kong Dollar"},
+	"HUF": {Code: "HUF", Numeric: "348", Exponent: 2, Symbol: "Ft", Name: "Forint"},
+	"IDR": {Code: "IDR", Numeric: "360", Exponent: 2, Symbol: "Rp", Name: "Rupiah"},
+	"ILS": {Code: "ILS", Numeric: "376", Exponent: 2, Symbol: "₪", Name: "New Israeli Sheqel"},
+	"INR": {Code: "INR", Numeric: "356", Exponent: 2, Symbol: "₹", Name: "Indian Rupee"},
+	"IQD": {Code: "IQD", Numeric: "368", Exponent: 3, Symbol: "IQD", Name: "Iraqi Dinar"},
+	"ISK": {Code: "ISK", Numeric: "352", Exponent: 0, Symbol: "kr", Name: "Iceland Krona"},
+	"JOD": {Code: "JOD", Numeric: "400", Exponent: 3, Symbol: "JD", Name: "Jordanian Dinar"},
+	"JPY": {Code: "JPY", Numeric: "392", Exponent: 0, Symbol: "¥", Name: "Yen"},
+	"KRW": {Code: "KRW", Numeric: "410", Exponent: 0, Symbol: "₩", Name: "Won"},
+	"KWD": {Code: "KWD", Numeric: "414", Exponent: 3, Symbol: "KD", Name: "Kuwaiti Dinar"},
+	"LYD": {Code: "LYD", Numeric: "434", Exponent: 3, Symbol: "LD", Name: "Libyan Dinar"},
+	"MXN": {Code: "MXN", Numeric: "484", Exponent: 2, Symbol: "MX$", Name: "Mexican Peso"},
+	"NOK": {Code: "NOK", Numeric: "578", Exponent: 2, Symbol: "kr", Name: "Norwegian Krone"},
+	"NZD": {Code: "NZD", Numeric: "554", Exponent: 2, Symbol: "NZ$", Name: "New Zealand Dollar"},
+	"OMR": {Code: "OMR", Numeric: "512", Exponent: 3, Symbol: "OMR", Name: "Rial Omani"},
+	"PHP": {Code: "PHP", Numeric: "608", Exponent: 2, Symbol: "₱", Name: "Philippine Peso"},
+	"PLN": {Code: "PLN", Numeric: "985", Exponent: 2, Symbol: "zł", Name: "Zloty"},
+	"SEK": {Code: "SEK", Numeric: "752", Exponent: 2, Symbol: "kr", Name: "Swedish Krona"},
+	"SGD": {Code: "SGD", Numeric: "702", Exponent: 2, Symbol: "S$", Name: "Singapore Dollar"},
+	"THB": {Code: "THB", Numeric: "764", Exponent: 2, Symbol: "฿", Name: "Baht"},
+	"TND": {Code: "TND", Numeric: "788", Exponent: 3, Symbol: "DT", Name: "Tunisian Dinar"},
+	"TRY": {Code: "TRY", Numeric: "949", Exponent: 2, Symbol: "₺", Name: "Turkish Lira"},
+	"USD": {Code: "USD", Numeric: "840", Exponent: 2, Symbol: "$", Name: "US Dollar"},
+	"VND": {Code: "VND", Numeric: "704", Exponent: 0, Symbol: "₫", Name: "Dong"},
+	"ZAR": {Code: "ZAR", Numeric: "710", Exponent: 2, Symbol: "R", Name: "Rand"},
+}
+
+// LookupCurrency returns the Currency for an ISO 4217 alphabetic code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+// String implements the stringer interface for Currency.
+func (c Currency) String() string {
+	return c.Code
+}
+
+// Amount is a monetary value in the minor units of a currency, ie €1 = 100, ¥1 = 1, KD1 = 1000.
+type Amount struct {
+	minor    int64
+	currency Currency
+}
+
+// NewAmount returns an Amount of minor units in the currency with the given ISO 4217 code.
+func NewAmount(minor int64, code string) (Amount, error) {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{minor: minor, currency: c}, nil
+}
+
+// FromMoney returns m as a US Dollar Amount.
+func FromMoney(m Money) Amount {
+	return Amount{minor: int64(m), currency: currencies["USD"]}
+}
+
+// Minor returns the amount in minor units.
+func (a Amount) Minor() int64 {
+	return a.minor
+}
+
+// Currency returns the currency of the amount.
+func (a Amount) Currency() Currency {
+	return a.currency
+}
+
+// IsZero reports whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a.minor == 0
+}
+
+// IsNegative reports whether the amount is less than zero.
+func (a Amount) IsNegative() bool {
+	return a.minor < 0
+}
+
+// SameCurrency reports whether a and b are in the same currency.
+func (a Amount) SameCurrency(b Amount) bool {
+	return a.currency.Code == b.currency.Code
+}
+
+// Add returns a + b; both amounts must be in the same currency.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.check(b); err != nil {
+		return Amount{}, err
+	}
+	v, err := checked("+", a, b, new(big.Int).Add(big.NewInt(a.minor), big.NewInt(b.minor)))
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{minor: int64(v), currency: a.currency}, nil
+}
+
+// Sub returns a - b; both amounts must be in the same currency.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if err := a.check(b); err != nil {
+		return Amount{}, err
+	}
+	v, err := checked("-", a, b, new(big.Int).Sub(big.NewInt(a.minor), big.NewInt(b.minor)))
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{minor: int64(v), currency: a.currency}, nil
+}
+
+// Cmp compares a and b and returns -1, 0 or +1; both amounts must be in the same currency.
+func (a Amount) Cmp(b Amount) (int, error) {
+	if err := a.check(b); err != nil {
+		return 0, err
+	}
+	switch {
+	case a.minor < b.minor:
+		return -1, nil
+	case a.minor > b.minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Equal reports whether a and b have the same currency and value.
+func (a Amount) Equal(b Amount) bool {
+	return a.SameCurrency(b) && a.minor == b.minor
+}
+
+// String implements the stringer interface for Amount, eg -€1,234.56 or ¥1,235.
+func (a Amount) String() string {
+	sign := ""
+	if a.minor < 0 {
+		sign = "-"
+	}
+	return sign + a.currency.Symbol + LocaleUS.number(a.minor, a.currency.Exponent)
+}
+
+func (a Amount) check(b Amount) error {
+	if !a.SameCurrency(b) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.currency, b.currency)
+	}
+	return nil
+}
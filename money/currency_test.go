@@ -0,0 +1,54 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	eur, err := money.LookupCurrency("eur")
+	should.NotBeError(t, err)
+	should.BeEqual(t, eur.Code, "EUR")
+	should.BeEqual(t, eur.Numeric, "978")
+	should.BeEqual(t, eur.Exponent, 2)
+	jpy, err := money.LookupCurrency("JPY")
+	should.NotBeError(t, err)
+	should.BeEqual(t, jpy.Exponent, 0)
+	kwd, err := money.LookupCurrency("KWD")
+	should.NotBeError(t, err)
+	should.BeEqual(t, kwd.Exponent, 3)
+	_, err = money.LookupCurrency("XYZ")
+	should.BeErrorIs(t, err, money.ErrUnknownCurrency)
+}
+
+func TestAmountString(t *testing.T) {
+	eur, _ := money.NewAmount(123456, "EUR")
+	should.BeEqual(t, eur.String(), "€1,234.56")
+	jpy, _ := money.NewAmount(-1235, "JPY")
+	should.BeEqual(t, jpy.String(), "-¥1,235")
+	kwd, _ := money.NewAmount(1234, "KWD")
+	should.BeEqual(t, kwd.String(), "KD1.234")
+	should.BeEqual(t, money.FromMoney(money.Money(5)).String(), "$0.05")
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a, _ := money.NewAmount(1000, "EUR")
+	b, _ := money.NewAmount(250, "EUR")
+	c, _ := money.NewAmount(250, "JPY")
+	sum, err := a.Add(b)
+	should.NotBeError(t, err)
+	should.BeEqual(t, sum.Minor(), int64(1250))
+	diff, err := b.Sub(a)
+	should.NotBeError(t, err)
+	should.BeTrue(t, diff.IsNegative())
+	_, err = a.Add(c)
+	should.BeErrorIs(t, err, money.ErrCurrencyMismatch)
+	_, err = a.Cmp(c)
+	should.BeErrorIs(t, err, money.ErrCurrencyMismatch)
+	cmp, err := a.Cmp(b)
+	should.NotBeError(t, err)
+	should.BeEqual(t, cmp, 1)
+	should.BeFalse(t, b.Equal(c))
+}
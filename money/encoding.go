@@ -0,0 +1,163 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ErrUnsupportedType is returned when decoding Money from an unsupported type.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+const (
+	yamlIntTag   = "!!int"
+	yamlFloatTag = "!!float"
+)
+
+// Decimal returns m as a plain decimal string without symbol or grouping, eg -1234.56.
+// This is the form used when encoding Money as text, JSON, YAML or SQL.
+func (m Money) Decimal() string {
+	return Formatter{}.Format(m)
+}
+
+// MarshalText implements encoding.TextMarshaler using the Decimal form.
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting anything Parse accepts.
+func (m *Money) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler; Money is encoded as a decimal string, eg "1234.56".
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.Decimal())), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Integers are legacy values in cents; other numbers are decimal amounts, eg 12.5 or 1.5e2, and
+// strings are parsed as by UnmarshalText, eg "1234.56", as UnmarshalYAML does.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrSyntax, data)
+		}
+		return m.UnmarshalText([]byte(s))
+	case bytes.ContainsAny(data, ".eE"):
+		return m.number(string(data))
+	default:
+		return m.legacy(string(data))
+	}
+}
+
+// MarshalYAML implements yaml.Marshaler; Money is encoded as a decimal string.
+func (m Money) MarshalYAML() (any, error) {
+	return m.Decimal(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Integers are legacy values in cents, floats are decimal amounts, eg 1234.56 or 1.5e2, and anything
+// else is parsed as by UnmarshalText, eg "$1,234.56".
+func (m *Money) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%w: yaml line %d is not a scalar", ErrUnsupportedType, node.Line)
+	}
+	switch node.ShortTag() {
+	case yamlIntTag:
+		return m.legacy(node.Value)
+	case yamlFloatTag:
+		if err := m.number(node.Value); err == nil || !errors.Is(err, ErrSyntax) {
+			return err
+		}
+	}
+	return m.UnmarshalText([]byte(node.Value))
+}
+
+// UnmarshalTOML implements the Unmarshaler interface of github.com/BurntSushi/toml, matching UnmarshalYAML:
+// integers are legacy values in cents, floats are decimal amounts and strings are parsed as by UnmarshalText.
+func (m *Money) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case int64:
+		return m.legacy(strconv.FormatInt(v, 10))
+	case float64:
+		return m.UnmarshalText([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	case string:
+		return m.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("%w: cannot decode %T into Money", ErrUnsupportedType, value)
+	}
+}
+
+// Value implements driver.Valuer; Money is stored as a decimal string suitable for a NUMERIC column.
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
+
+// Scan implements sql.Scanner.
+// Integers are legacy values in cents; strings and bytes, as returned for NUMERIC columns, are parsed as decimals.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		return m.legacy(strconv.FormatInt(v, 10))
+	case float64:
+		f, err := FromString(strconv.FormatFloat(v, 'f', -1, 64))
+		if err != nil {
+			return err
+		}
+		*m = f
+		return nil
+	case []byte:
+		return m.UnmarshalText(v)
+	case string:
+		return m.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Money", ErrUnsupportedType, src)
+	}
+}
+
+// legacy decodes an integer number of cents.
+func (m *Money) legacy(s string) error {
+	cents, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return &ParseError{Input: s, Offset: 0, Err: ErrSyntax, Detail: "not an integer number of cents"}
+	}
+	v, err := FromCents(cents)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// number sets m from a JSON or YAML number in dollars, eg 12.5 or 1.5e2, which must be a whole number of cents.
+func (m *Money) number(s string) error {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return &ParseError{Input: s, Offset: 0, Err: ErrSyntax, Detail: "not a number"}
+	}
+	r.Mul(r, big.NewRat(dollarInCents, 1))
+	if !r.IsInt() {
+		return &ParseError{Input: s, Offset: 0, Err: ErrPrecision, Detail: "fraction of a cent"}
+	}
+	if r.Num().CmpAbs(bigMax) > 0 {
+		return &ParseError{Input: s, Offset: 0, Err: ErrOverflow, Detail: "exceeds ceiling"}
+	}
+	*m = Money(r.Num().Int64())
+	return nil
+}
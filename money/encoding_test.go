@@ -0,0 +1,96 @@
+package money_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"go.yaml.in/yaml/v4"
+)
+
+type invoice struct {
+	Total money.Money `json:"total" yaml:"total"`
+}
+
+func TestDecimal(t *testing.T) {
+	should.BeEqual(t, money.Money(123456).Decimal(), "1234.56")
+	should.BeEqual(t, money.Money(-5).Decimal(), "-0.05")
+}
+
+func TestText(t *testing.T) {
+	text, err := money.Money(-123456).MarshalText()
+	should.NotBeError(t, err)
+	should.BeEqual(t, string(text), "-1234.56")
+	var m money.Money
+	should.NotBeError(t, m.UnmarshalText([]byte("$1,234.56")))
+	should.BeEqual(t, m, money.Money(123456))
+	should.BeErrorIs(t, m.UnmarshalText([]byte("abc")), money.ErrSyntax)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(invoice{Total: 123456})
+	should.NotBeError(t, err)
+	should.BeEqual(t, string(data), `{"total":"1234.56"}`)
+	var inv invoice
+	should.NotBeError(t, json.Unmarshal(data, &inv))
+	should.BeEqual(t, inv.Total, money.Money(123456))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":-789}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(-789))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":null}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(-789))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":12.5}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(1250))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":-0.07}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(-7))
+	should.BeErrorIs(t, json.Unmarshal([]byte(`{"total":12.345}`), &inv), money.ErrPrecision)
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":1e3}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(100000))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":1.5E2}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(15000))
+	should.NotBeError(t, json.Unmarshal([]byte(`{"total":-125e-2}`), &inv))
+	should.BeEqual(t, inv.Total, money.Money(-125))
+	should.BeErrorIs(t, json.Unmarshal([]byte(`{"total":1e-3}`), &inv), money.ErrPrecision)
+	should.BeErrorIs(t, json.Unmarshal([]byte(`{"total":1e20}`), &inv), money.ErrOverflow)
+	should.BeErrorIs(t, json.Unmarshal([]byte(`{"total":"1.005"}`), &inv), money.ErrPrecision)
+}
+
+func TestJSONMatchesYAML(t *testing.T) {
+	for _, value := range []string{"1234", "-5", "12.5", "0.07", "1e3", "1.5E2", `"$1,234.56"`, `"7"`} {
+		var fromJSON, fromYAML invoice
+		should.NotBeError(t, json.Unmarshal([]byte(`{"total":`+value+`}`), &fromJSON), should.WithMessage(value))
+		should.NotBeError(t, yaml.Unmarshal([]byte("total: "+value+"\n"), &fromYAML), should.WithMessage(value))
+		should.BeEqual(t, fromJSON.Total, fromYAML.Total, should.WithMessage(value))
+	}
+}
+
+func TestYAML(t *testing.T) {
+	data, err := yaml.Marshal(invoice{Total: 123456})
+	should.NotBeError(t, err)
+	var inv invoice
+	should.NotBeError(t, yaml.Unmarshal(data, &inv))
+	should.BeEqual(t, inv.Total, money.Money(123456))
+	should.NotBeError(t, yaml.Unmarshal([]byte("total: 12.34\n"), &inv))
+	should.BeEqual(t, inv.Total, money.Money(1234))
+	should.NotBeError(t, yaml.Unmarshal([]byte("total: 1234\n"), &inv))
+	should.BeEqual(t, inv.Total, money.Money(1234))
+	should.NotBeError(t, yaml.Unmarshal([]byte("total: $5\n"), &inv))
+	should.BeEqual(t, inv.Total, money.Money(500))
+	should.BeError(t, yaml.Unmarshal([]byte("total: [1]\n"), &inv))
+}
+
+func TestSQL(t *testing.T) {
+	v, err := money.Money(-123456).Value()
+	should.NotBeError(t, err)
+	should.BeEqual(t, v, any("-1234.56"))
+	var m money.Money
+	should.NotBeError(t, m.Scan(int64(4200)))
+	should.BeEqual(t, m, money.Money(4200))
+	should.NotBeError(t, m.Scan([]byte("12.30")))
+	should.BeEqual(t, m, money.Money(1230))
+	should.NotBeError(t, m.Scan("7"))
+	should.BeEqual(t, m, money.Money(700))
+	should.NotBeError(t, m.Scan(1.005))
+	should.BeEqual(t, m, money.Money(101))
+	should.BeErrorIs(t, m.Scan(nil), money.ErrUnsupportedType)
+}
@@ -0,0 +1,272 @@
+package money
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoRate is returned when no exchange rate is available for a currency pair at a time.
+	ErrNoRate = errors.New("no exchange rate")
+	// ErrInvalidRate is returned when an exchange or interest rate is missing, not a number or out of
+	// range, or when a tax rate is written as a plain number of 1 or more, eg 7 rather than 7% or 0.07.
+	ErrInvalidRate = errors.New("invalid rate")
+)
+
+// ExchangeRate is the price of one unit of From in units of To, effective from a point in time.
+type ExchangeRate struct {
+	From      string   // ISO 4217 code
+	To        string   // ISO 4217 code
+	Rate      *big.Rat // units of To per unit of From, eg 1.0825 for EUR to USD
+	Effective time.Time
+}
+
+// ExchangeRateProvider returns the exchange rate for a currency pair in effect at a time.
+type ExchangeRateProvider interface {
+	Rate(from, to string, at time.Time) (ExchangeRate, error)
+}
+
+// Conversion records a currency conversion so that it can be audited and replayed.
+type Conversion struct {
+	From     Amount
+	To       Amount
+	Rate     ExchangeRate
+	Rounding Rounding
+	At       time.Time // the time the rate was requested for
+}
+
+// Convert converts a into the currency with code to, using the rate p has in effect at the time at.
+// The result is rounded to the minor units of the target currency with the optional mode, which
+// defaults to RoundHalfUp.
+func Convert(a Amount, to string, p ExchangeRateProvider, at time.Time, mode ...Rounding) (Conversion, error) {
+	rate, err := p.Rate(a.currency.Code, to, at)
+	if err != nil {
+		return Conversion{}, err
+	}
+	if !strings.EqualFold(rate.To, to) {
+		return Conversion{}, fmt.Errorf("%w: rate is for %s/%s, want %s", ErrCurrencyMismatch, rate.From, rate.To, to)
+	}
+	if rate.Rate != nil {
+		rate.Rate = new(big.Rat).Set(rate.Rate) // the record must not share the provider's rate
+	}
+	c := Conversion{From: a, To: Amount{}, Rate: rate, Rounding: roundingMode(mode), At: at}
+	if c.To, err = c.Replay(); err != nil {
+		return Conversion{}, err
+	}
+	return c, nil
+}
+
+// Replay recalculates the converted amount from the recorded amount, rate and rounding mode.
+func (c Conversion) Replay() (Amount, error) {
+	if c.Rate.Rate == nil || c.Rate.Rate.Sign() <= 0 {
+		return Amount{}, fmt.Errorf("%w: %s/%s", ErrInvalidRate, c.Rate.From, c.Rate.To)
+	}
+	if c.Rate.From != c.From.currency.Code {
+		return Amount{}, fmt.Errorf("%w: rate is for %s, amount is %s", ErrCurrencyMismatch, c.Rate.From, c.From.currency)
+	}
+	to, err := LookupCurrency(c.Rate.To)
+	if err != nil {
+		return Amount{}, err
+	}
+	// scale between the minor units of the two currencies, eg 1000/100 for USD to KWD
+	scale := new(big.Rat).SetFrac(pow10(to.Exponent), pow10(c.From.currency.Exponent))
+	r := new(big.Rat).SetInt64(c.From.minor)
+	r.Mul(r, c.Rate.Rate).Mul(r, scale)
+	minor, err := checked("*", c.From, c.Rate.Rate.RatString(), c.Rounding.round(r))
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{minor: int64(minor), currency: to}, nil
+}
+
+// MemoryRates is an in memory ExchangeRateProvider, safe for concurrent use.
+// A rate for EUR to USD also serves USD to EUR using its inverse.
+type MemoryRates struct {
+	mu    sync.RWMutex
+	rates map[string][]ExchangeRate // sorted by Effective
+}
+
+// NewMemoryRates returns a MemoryRates holding rates.
+func NewMemoryRates(rates ...ExchangeRate) (*MemoryRates, error) {
+	m := &MemoryRates{mu: sync.RWMutex{}, rates: map[string][]ExchangeRate{}}
+	for _, r := range rates {
+		if err := m.Set(r); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Set adds a copy of a rate, replacing any rate for the same pair with the same effective time.
+func (m *MemoryRates) Set(r ExchangeRate) error {
+	if r.Rate == nil || r.Rate.Sign() <= 0 {
+		return fmt.Errorf("%w: %s/%s", ErrInvalidRate, r.From, r.To)
+	}
+	r.Rate = new(big.Rat).Set(r.Rate)
+	r.From, r.To = strings.ToUpper(r.From), strings.ToUpper(r.To)
+	for _, code := range []string{r.From, r.To} {
+		if _, err := LookupCurrency(code); err != nil {
+			return err
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := r.From + "/" + r.To
+	rates := slices.DeleteFunc(m.rates[key], func(e ExchangeRate) bool {
+		return e.Effective.Equal(r.Effective)
+	})
+	i, _ := slices.BinarySearchFunc(rates, r.Effective, func(e ExchangeRate, t time.Time) int {
+		return e.Effective.Compare(t)
+	})
+	m.rates[key] = slices.Insert(rates, i, r)
+	return nil
+}
+
+// Rate implements ExchangeRateProvider, returning a copy of the latest rate effective at or before at.
+func (m *MemoryRates) Rate(from, to string, at time.Time) (ExchangeRate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return ExchangeRate{From: from, To: to, Rate: big.NewRat(1, 1), Effective: time.Time{}}, nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if r, ok := latest(m.rates[from+"/"+to], at); ok {
+		r.Rate = new(big.Rat).Set(r.Rate)
+		return r, nil
+	}
+	if r, ok := latest(m.rates[to+"/"+from], at); ok {
+		return ExchangeRate{From: from, To: to, Rate: new(big.Rat).Inv(r.Rate), Effective: r.Effective}, nil
+	}
+	return ExchangeRate{}, fmt.Errorf("%w: %s/%s at %s", ErrNoRate, from, to, at.Format(time.RFC3339))
+}
+
+func latest(rates []ExchangeRate, at time.Time) (ExchangeRate, bool) {
+	for i := len(rates) - 1; i >= 0; i-- {
+		if !rates[i].Effective.After(at) {
+			return rates[i], true
+		}
+	}
+	return ExchangeRate{}, false
+}
+
+// FileRates is an ExchangeRateProvider backed by a rate table in a CSV or JSON file.
+//
+// CSV files have a header row naming the from, to, rate and effective columns, in any order;
+// JSON files hold an array of objects with the same keys. Rates are decimal strings and effective dates are RFC 3339 or 2006-01-02.
+type FileRates struct {
+	*MemoryRates
+
+	path string
+}
+
+// NewFileRates loads the rate table at path; the format is chosen by the .csv or .json extension.
+func NewFileRates(path string) (*FileRates, error) {
+	f := &FileRates{MemoryRates: &MemoryRates{mu: sync.RWMutex{}, rates: map[string][]ExchangeRate{}}, path: path}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload rereads the rate table, replacing all rates; on error the previous rates are kept.
+func (f *FileRates) Reload() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return fmt.Errorf("open rates %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+	var records []rateRecord
+	switch ext := strings.ToLower(filepath.Ext(f.path)); ext {
+	case ".csv":
+		records, err = readCSV(file)
+	case ".json":
+		err = json.NewDecoder(file).Decode(&records)
+	default:
+		err = fmt.Errorf("%w: rate file extension %q", ErrUnsupportedType, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("read rates %s: %w", f.path, err)
+	}
+	rates := make([]ExchangeRate, 0, len(records))
+	for i, rec := range records {
+		r, err := rec.rate()
+		if err != nil {
+			return fmt.Errorf("read rates %s: record %d: %w", f.path, i+1, err)
+		}
+		rates = append(rates, r)
+	}
+	m, err := NewMemoryRates(rates...)
+	if err != nil {
+		return fmt.Errorf("read rates %s: %w", f.path, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rates = m.rates
+	return nil
+}
+
+type rateRecord struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Rate      string `json:"rate"`
+	Effective string `json:"effective"`
+}
+
+func (rec rateRecord) rate() (ExchangeRate, error) {
+	rate, ok := new(big.Rat).SetString(rec.Rate)
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rec.Rate)
+	}
+	effective, err := time.Parse(time.RFC3339, rec.Effective)
+	if err != nil {
+		if effective, err = time.Parse(time.DateOnly, rec.Effective); err != nil {
+			return ExchangeRate{}, fmt.Errorf("effective date %w", err)
+		}
+	}
+	return ExchangeRate{From: rec.From, To: rec.To, Rate: rate, Effective: effective}, nil
+}
+
+func readCSV(r io.Reader) ([]rateRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	columns := map[string]int{"from": -1, "to": -1, "rate": -1, "effective": -1}
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if j, ok := columns[name]; !ok || j >= 0 {
+			return nil, fmt.Errorf("%w: csv header: unknown or repeated column %q", ErrSyntax, name)
+		}
+		columns[name] = i
+	}
+	records := make([]rateRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		records = append(records, rateRecord{
+			From:      row[columns["from"]],
+			To:        row[columns["to"]],
+			Rate:      row[columns["rate"]],
+			Effective: row[columns["effective"]],
+		})
+	}
+	return records, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil) //nolint:mnd
+}
@@ -0,0 +1,147 @@
+package money_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func rat(s string) *big.Rat {
+	r, _ := new(big.Rat).SetString(s)
+	return r
+}
+
+func TestConvert(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	rates, err := money.NewMemoryRates(
+		money.ExchangeRate{From: "EUR", To: "USD", Rate: rat("1.10"), Effective: jan},
+		money.ExchangeRate{From: "EUR", To: "USD", Rate: rat("1.0825"), Effective: feb},
+		money.ExchangeRate{From: "USD", To: "JPY", Rate: rat("151.237"), Effective: jan},
+		money.ExchangeRate{From: "USD", To: "KWD", Rate: rat("0.30812"), Effective: jan},
+	)
+	should.NotBeError(t, err)
+	eur, _ := money.NewAmount(10000, "EUR")
+
+	c, err := money.Convert(eur, "USD", rates, jan.AddDate(0, 0, 10))
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.Minor(), int64(11000))
+	should.BeTrue(t, c.Rate.Effective.Equal(jan))
+
+	c, err = money.Convert(eur, "USD", rates, feb, money.RoundHalfEven)
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.Minor(), int64(10825))
+	replayed, err := c.Replay()
+	should.NotBeError(t, err)
+	should.BeTrue(t, replayed.Equal(c.To))
+
+	usd, _ := money.NewAmount(1999, "USD")
+	c, err = money.Convert(usd, "JPY", rates, feb)
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.String(), "¥3,023")
+	c, err = money.Convert(usd, "KWD", rates, feb)
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.String(), "KD6.159")
+
+	jpy, _ := money.NewAmount(15124, "JPY")
+	c, err = money.Convert(jpy, "USD", rates, feb)
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.Minor(), int64(10000))
+
+	_, err = money.Convert(eur, "USD", rates, jan.AddDate(0, 0, -1))
+	should.BeErrorIs(t, err, money.ErrNoRate)
+	_, err = money.Convert(eur, "GBP", rates, feb)
+	should.BeErrorIs(t, err, money.ErrNoRate)
+	_, err = money.NewMemoryRates(money.ExchangeRate{From: "EUR", To: "USD", Rate: rat("0"), Effective: jan})
+	should.BeErrorIs(t, err, money.ErrInvalidRate)
+}
+
+func TestMemoryRatesCopies(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := rat("1.10")
+	rates, err := money.NewMemoryRates(money.ExchangeRate{From: "EUR", To: "USD", Rate: in, Effective: jan})
+	should.NotBeError(t, err)
+	in.SetInt64(5)
+
+	r, err := rates.Rate("EUR", "USD", jan)
+	should.NotBeError(t, err)
+	should.BeEqual(t, r.Rate.RatString(), "11/10")
+	r.Rate.Mul(r.Rate, big.NewRat(2, 1))
+
+	eur, _ := money.NewAmount(10000, "EUR")
+	c, err := money.Convert(eur, "USD", rates, jan)
+	should.NotBeError(t, err)
+	should.BeEqual(t, c.To.Minor(), int64(11000))
+	c.Rate.Rate.Mul(c.Rate.Rate, big.NewRat(2, 1))
+	again, err := money.Convert(eur, "USD", rates, jan)
+	should.NotBeError(t, err)
+	should.BeEqual(t, again.To.Minor(), int64(11000))
+	replayed, err := again.Replay()
+	should.NotBeError(t, err)
+	should.BeTrue(t, replayed.Equal(again.To))
+}
+
+func TestFileRates(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "rates.csv")
+	should.NotBeError(t, os.WriteFile(csvFile, []byte(
+		"from,to,rate,effective\nEUR,USD,1.10,2025-01-01\nEUR,USD,1.0825,2025-02-01T00:00:00Z\n"), 0o600))
+	jsonFile := filepath.Join(dir, "rates.json")
+	should.NotBeError(t, os.WriteFile(jsonFile, []byte(
+		`[{"from":"GBP","to":"USD","rate":"1.25","effective":"2025-01-01"}]`), 0o600))
+
+	rates, err := money.NewFileRates(csvFile)
+	should.NotBeError(t, err)
+	r, err := rates.Rate("EUR", "USD", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	should.NotBeError(t, err)
+	should.BeEqual(t, r.Rate.RatString(), "433/400")
+
+	rates, err = money.NewFileRates(jsonFile)
+	should.NotBeError(t, err)
+	r, err = rates.Rate("USD", "GBP", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	should.NotBeError(t, err)
+	should.BeEqual(t, r.Rate.RatString(), "4/5")
+
+	should.NotBeError(t, os.WriteFile(jsonFile, []byte(`[{"from":"GBP","to":"USD","rate":"x","effective":"2025-01-01"}]`), 0o600))
+	should.BeErrorIs(t, rates.Reload(), money.ErrInvalidRate)
+	_, err = rates.Rate("USD", "GBP", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	should.NotBeError(t, err)
+
+	_, err = money.NewFileRates(filepath.Join(dir, "rates.txt"))
+	should.BeError(t, err)
+}
+
+func TestFileRatesHeader(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "rates.csv")
+	should.NotBeError(t, os.WriteFile(csvFile, []byte("to, From,effective,RATE\nUSD,EUR,2025-01-01,1.10\n"), 0o600))
+	rates, err := money.NewFileRates(csvFile)
+	should.NotBeError(t, err)
+	r, err := rates.Rate("EUR", "USD", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	should.NotBeError(t, err)
+	should.BeEqual(t, r.Rate.RatString(), "11/10")
+
+	for _, header := range []string{"from,to,rate,date", "from,to,rate,rate", "EUR,USD,1.10,2025-01-01"} {
+		should.NotBeError(t, os.WriteFile(csvFile, []byte(header+"\nEUR,USD,1.10,2025-01-01\n"), 0o600))
+		_, err = money.NewFileRates(csvFile)
+		should.BeErrorIs(t, err, money.ErrSyntax, should.WithMessage(header))
+	}
+}
+
+// wrongRates returns a rate into the wrong currency.
+type wrongRates struct{}
+
+func (wrongRates) Rate(from, _ string, at time.Time) (money.ExchangeRate, error) {
+	return money.ExchangeRate{From: from, To: "JPY", Rate: rat("150"), Effective: at}, nil
+}
+
+func TestConvertWrongRate(t *testing.T) {
+	usd, _ := money.NewAmount(100, "USD")
+	_, err := money.Convert(usd, "EUR", wrongRates{}, time.Now())
+	should.BeErrorIs(t, err, money.ErrCurrencyMismatch)
+}
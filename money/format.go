@@ -0,0 +1,133 @@
+package money
+
+import (
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Locale describes how money is written in a region.
+type Locale struct {
+	Symbol      string // currency symbol, eg $ or €
+	Code        string // ISO 4217 code, eg USD
+	Decimal     string // decimal separator, eg . or ,
+	Group       string // digit grouping separator, eg , or . or a space
+	Grouping    []int  // group sizes from the right, the last repeats; nil means groups of three
+	SymbolAfter bool   // symbol follows the number, eg 1.234,56 €
+	SymbolSpace bool   // symbol and number are separated by a space
+}
+
+// Predefined locales.
+//
+//nolint:gochecknoglobals,mnd
+var (
+	LocaleUS = Locale{Symbol: "$", Code: "USD", Decimal: ".", Group: ","}
+	LocaleGB = Locale{Symbol: "£", Code: "GBP", Decimal: ".", Group: ","}
+	LocaleDE = Locale{Symbol: "€", Code: "EUR", Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true}
+	LocaleFR = Locale{Symbol: "€", Code: "EUR", Decimal: ",", Group: " ", SymbolAfter: true, SymbolSpace: true}
+	LocaleIN = Locale{Symbol: "₹", Code: "INR", Decimal: ".", Group: ",", Grouping: []int{3, 2}}
+	LocaleJP = Locale{Symbol: "¥", Code: "JPY", Decimal: ".", Group: ","}
+)
+
+// Formatter formats money according to a Locale.
+type Formatter struct {
+	Locale     Locale
+	Accounting bool // write negative values in parentheses, eg ($5.00)
+	UseCode    bool // write the ISO code instead of the symbol, eg USD 5.00
+}
+
+// Format returns m formatted with two decimal places.
+func (f Formatter) Format(m Money) string {
+	return f.format(m < 0, f.Locale.number(int64(m), centsExponent), f.Locale.Symbol, f.Locale.Code)
+}
+
+// FormatAmount returns a formatted with the symbol and minor units of its currency.
+func (f Formatter) FormatAmount(a Amount) string {
+	number := f.Locale.number(a.minor, a.currency.Exponent)
+	return f.format(a.minor < 0, number, a.currency.Symbol, a.currency.Code)
+}
+
+// FormatBig returns b formatted with all of its decimal places.
+func (f Formatter) FormatBig(b BigMoney) string {
+	number := f.Locale.digits(new(big.Int).Abs(b.int()).String(), b.scale)
+	return f.format(b.Sign() < 0, number, f.Locale.Symbol, f.Locale.Code)
+}
+
+func (f Formatter) format(neg bool, number, symbol, code string) string {
+	space := f.Locale.SymbolSpace
+	if f.UseCode {
+		symbol = code
+		space = true
+	}
+	switch {
+	case symbol == "":
+	case f.Locale.SymbolAfter && space:
+		number += " " + symbol
+	case f.Locale.SymbolAfter:
+		number += symbol
+	case space:
+		number = symbol + " " + number
+	default:
+		number = symbol + number
+	}
+	switch {
+	case !neg:
+		return number
+	case f.Accounting:
+		return "(" + number + ")"
+	default:
+		return "-" + number
+	}
+}
+
+// number formats the absolute value of minor units with the locale's separators and exponent decimal places.
+func (l Locale) number(minor int64, exponent int) string {
+	abs := uint64(minor)
+	if minor < 0 {
+		abs = -abs
+	}
+	return l.digits(strconv.FormatUint(abs, 10), exponent)
+}
+
+// digits formats a string of digits with the locale's separators and exponent decimal places.
+func (l Locale) digits(digits string, exponent int) string {
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+	whole = groupDigits(whole, l.Group, l.Grouping)
+	if exponent == 0 {
+		return whole
+	}
+	return whole + l.decimal() + frac
+}
+
+func (l Locale) decimal() string {
+	if l.Decimal == "" {
+		return "."
+	}
+	return l.Decimal
+}
+
+// groupDigits inserts sep between groups of digits, sized from the right by sizes.
+func groupDigits(digits, sep string, sizes []int) string {
+	if sep == "" {
+		return digits
+	}
+	if len(sizes) == 0 {
+		sizes = []int{three}
+	}
+	var groups []string
+	for i := 0; ; i++ {
+		size := sizes[min(i, len(sizes)-1)]
+		if size <= 0 || len(digits) <= size {
+			groups = append(groups, digits)
+			break
+		}
+		groups = append(groups, digits[len(digits)-size:])
+		digits = digits[:len(digits)-size]
+	}
+	slices.Reverse(groups)
+	return strings.Join(groups, sep)
+}
@@ -0,0 +1,50 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestFormat(t *testing.T) {
+	us := money.Formatter{Locale: money.LocaleUS}
+	should.BeEqual(t, us.Format(money.Money(123456)), "$1,234.56")
+	should.BeEqual(t, us.Format(money.Money(-789)), "-$7.89")
+	should.BeEqual(t, us.Format(money.Money(5)), "$0.05")
+	accounting := money.Formatter{Locale: money.LocaleUS, Accounting: true}
+	should.BeEqual(t, accounting.Format(money.Money(-500)), "($5.00)")
+	code := money.Formatter{Locale: money.LocaleUS, UseCode: true}
+	should.BeEqual(t, code.Format(money.Money(-500)), "-USD 5.00")
+	de := money.Formatter{Locale: money.LocaleDE}
+	should.BeEqual(t, de.Format(money.Money(123456789)), "1.234.567,89 €")
+	deCode := money.Formatter{Locale: money.LocaleDE, UseCode: true}
+	should.BeEqual(t, deCode.Format(money.Money(100)), "1,00 EUR")
+	in := money.Formatter{Locale: money.LocaleIN}
+	should.BeEqual(t, in.Format(money.Money(1234567890)), "₹1,23,45,678.90")
+	should.BeEqual(t, in.Format(money.Money(99900)), "₹999.00")
+	should.BeEqual(t, money.Formatter{}.Format(money.Money(123456)), "1234.56")
+}
+
+func TestFormatAmount(t *testing.T) {
+	jpy, _ := money.NewAmount(1234567, "JPY")
+	should.BeEqual(t, money.Formatter{Locale: money.LocaleJP}.FormatAmount(jpy), "¥1,234,567")
+	kwd, _ := money.NewAmount(-1234567, "KWD")
+	f := money.Formatter{Locale: money.LocaleDE, Accounting: true}
+	should.BeEqual(t, f.FormatAmount(kwd), "(1.234,567 KD)")
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	for _, f := range []money.Formatter{
+		{Locale: money.LocaleUS, Accounting: true},
+		{Locale: money.LocaleDE, UseCode: true},
+		{Locale: money.LocaleFR},
+		{Locale: money.LocaleIN},
+	} {
+		for _, m := range []money.Money{0, -5, 123456789} {
+			got, err := money.ParseLocale(f.Format(m), f.Locale)
+			should.NotBeError(t, err)
+			should.BeEqual(t, got, m)
+		}
+	}
+}
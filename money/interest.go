@@ -0,0 +1,132 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidTerm is returned when a number of periods is less than one.
+var ErrInvalidTerm = errors.New("invalid term")
+
+// Period is one row of an amortisation Schedule.
+type Period struct {
+	N         int // 1 based period number
+	Payment   Money
+	Interest  Money
+	Principal Money
+	Balance   Money // outstanding after the payment
+}
+
+// Schedule is an amortisation schedule for a loan; the final balance is always zero.
+type Schedule struct {
+	Payment       Money // the regular payment; the final payment absorbs any rounding residue
+	Periods       []Period
+	TotalInterest Money
+	TotalPaid     Money
+}
+
+// SimpleInterest returns the interest on m at rate per period for periods, ie m * rate * periods.
+// Rates are per period, eg 0.005 for 6% a year paid monthly, and the result is rounded with the
+// optional mode, which defaults to RoundHalfUp. Interest rates are floats rather than Rates, as a
+// periodic rate such as 0.05/12 is not exact in hundredths of a basis point.
+func (m Money) SimpleInterest(rate float64, periods int, mode ...Rounding) (Money, error) {
+	if periods < 0 {
+		return 0, fmt.Errorf("%w: %d periods", ErrInvalidTerm, periods)
+	}
+	r := new(big.Rat).Mul(ratFromFloat(rate), new(big.Rat).SetInt64(int64(periods)))
+	return m.MulRat(r, mode...)
+}
+
+// CompoundInterest returns the interest on m at rate compounded each period for periods,
+// ie FutureValue - m.
+func (m Money) CompoundInterest(rate float64, periods int, mode ...Rounding) (Money, error) {
+	fv, err := m.FutureValue(rate, periods, mode...)
+	if err != nil {
+		return 0, err
+	}
+	return fv.Sub(m)
+}
+
+// FutureValue returns the value of m after compounding at rate for periods, ie m * (1 + rate)^periods.
+func (m Money) FutureValue(rate float64, periods int, mode ...Rounding) (Money, error) {
+	growth, err := growth(rate, periods)
+	if err != nil {
+		return 0, err
+	}
+	return m.MulRat(growth, mode...)
+}
+
+// PresentValue returns the value today of m received after periods at rate, ie m / (1 + rate)^periods.
+func (m Money) PresentValue(rate float64, periods int, mode ...Rounding) (Money, error) {
+	growth, err := growth(rate, periods)
+	if err != nil {
+		return 0, err
+	}
+	return m.MulRat(growth.Inv(growth), mode...)
+}
+
+// Amortize returns the schedule of equal payments that repays principal m at rate per period over periods.
+// Interest for each period is rounded with the optional mode, which defaults to RoundHalfUp,
+// and the final payment absorbs the rounding residue so that the schedule ends at exactly zero.
+func (m Money) Amortize(rate float64, periods int, mode ...Rounding) (Schedule, error) {
+	if periods < 1 {
+		return Schedule{}, fmt.Errorf("%w: %d periods", ErrInvalidTerm, periods)
+	}
+	r := ratFromFloat(rate)
+	g, err := growth(rate, periods)
+	if err != nil {
+		return Schedule{}, err
+	}
+	// payment = m * r * g / (g - 1), or m / periods when there is no interest
+	factor := big.NewRat(1, int64(periods))
+	if r.Sign() != 0 {
+		factor = new(big.Rat).Quo(new(big.Rat).Mul(r, g), new(big.Rat).Sub(g, big.NewRat(1, 1)))
+	}
+	payment, err := m.MulRat(factor, mode...)
+	if err != nil {
+		return Schedule{}, err
+	}
+	s := Schedule{Payment: payment, Periods: make([]Period, 0, periods), TotalInterest: 0, TotalPaid: 0}
+	balance := m
+	for n := 1; n <= periods; n++ {
+		p := Period{N: n, Payment: payment, Interest: 0, Principal: 0, Balance: 0}
+		if p.Interest, err = balance.MulRat(r, mode...); err != nil {
+			return Schedule{}, err
+		}
+		p.Principal = payment - p.Interest
+		if n == periods {
+			p.Principal = balance
+			p.Payment = p.Interest + balance
+		}
+		balance -= p.Principal
+		p.Balance = balance
+		if s.TotalInterest, err = s.TotalInterest.Add(p.Interest); err != nil {
+			return Schedule{}, err
+		}
+		if s.TotalPaid, err = s.TotalPaid.Add(p.Payment); err != nil {
+			return Schedule{}, err
+		}
+		s.Periods = append(s.Periods, p)
+	}
+	return s, nil
+}
+
+// growth returns (1 + rate)^periods.
+func growth(rate float64, periods int) (*big.Rat, error) {
+	if periods < 0 {
+		return nil, fmt.Errorf("%w: %d periods", ErrInvalidTerm, periods)
+	}
+	base := new(big.Rat).Add(big.NewRat(1, 1), ratFromFloat(rate))
+	if base.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRate, rate)
+	}
+	result := big.NewRat(1, 1)
+	for ; periods > 0; periods >>= 1 {
+		if periods&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+	return result, nil
+}
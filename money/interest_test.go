@@ -0,0 +1,68 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestSimpleInterest(t *testing.T) {
+	i, err := money.Money(100000).SimpleInterest(0.05, 3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, i, money.Money(15000))
+	_, err = money.Money(100).SimpleInterest(0.05, -1)
+	should.BeErrorIs(t, err, money.ErrInvalidTerm)
+}
+
+func TestCompoundInterest(t *testing.T) {
+	i, err := money.Money(100000).CompoundInterest(0.05, 3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, i, money.Money(15763)) // 1157.625 rounds to 1157.63
+	i, err = money.Money(100000).CompoundInterest(0.05, 3, money.RoundHalfEven)
+	should.NotBeError(t, err)
+	should.BeEqual(t, i, money.Money(15762))
+	_, err = money.Money(100).CompoundInterest(-1, 3)
+	should.BeErrorIs(t, err, money.ErrInvalidRate)
+	_, err = money.Money(1e16).CompoundInterest(0.05, 1)
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestPresentFutureValue(t *testing.T) {
+	fv, err := money.Money(100000).FutureValue(0.1, 2)
+	should.NotBeError(t, err)
+	should.BeEqual(t, fv, money.Money(121000))
+	pv, err := money.Money(121000).PresentValue(0.1, 2)
+	should.NotBeError(t, err)
+	should.BeEqual(t, pv, money.Money(100000))
+	pv, err = money.Money(100000).PresentValue(0, 10)
+	should.NotBeError(t, err)
+	should.BeEqual(t, pv, money.Money(100000))
+}
+
+func TestAmortize(t *testing.T) {
+	s, err := money.Money(10000000).Amortize(0.005, 360)
+	should.NotBeError(t, err)
+	should.BeEqual(t, s.Payment, money.Money(59955))
+	should.HaveLength(t, s.Periods, 360)
+	should.BeEqual(t, s.Periods[0].Interest, money.Money(50000))
+	should.BeEqual(t, s.Periods[0].Principal, money.Money(9955))
+	last := s.Periods[359]
+	should.BeEqual(t, last.Balance, money.Money(0))
+	should.BeEqual(t, s.TotalPaid-s.TotalInterest, money.Money(10000000))
+	var principal money.Money
+	for _, p := range s.Periods {
+		should.BeEqual(t, p.Payment, p.Interest+p.Principal)
+		principal += p.Principal
+	}
+	should.BeEqual(t, principal, money.Money(10000000))
+
+	s, err = money.Money(1000).Amortize(0, 3)
+	should.NotBeError(t, err)
+	should.BeEqual(t, s.Periods[0].Payment, money.Money(333))
+	should.BeEqual(t, s.Periods[2].Payment, money.Money(334))
+	should.BeEqual(t, s.TotalInterest, money.Money(0))
+
+	_, err = money.Money(1000).Amortize(0.01, 0)
+	should.BeErrorIs(t, err, money.ErrInvalidTerm)
+}
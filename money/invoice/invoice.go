@@ -0,0 +1,318 @@
+// Package invoice builds invoices and receipts from money.Money line items, with
+// per line and per invoice discounts, tax categories and a choice of rounding strategy.
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+var (
+	// ErrUnknownCategory is returned when a line refers to a tax category that has not been defined.
+	ErrUnknownCategory = errors.New("unknown tax category")
+	// ErrInvalidQuantity is returned when a line has a quantity less than one.
+	ErrInvalidQuantity = errors.New("invalid quantity")
+)
+
+// Strategy determines when tax is rounded.
+type Strategy int
+
+const (
+	// PerLine rounds the tax on each line; the invoice tax is the sum of the line taxes.
+	PerLine Strategy = iota
+	// PerTotal rounds the tax once on the total of each category and allocates it back to the lines.
+	PerTotal
+)
+
+// Line is an item on an invoice.
+type Line struct {
+	Description string      `json:"description"`
+	Quantity    int64       `json:"quantity"`
+	UnitPrice   money.Money `json:"unitPrice"`
+	Discount    money.Rate  `json:"discountRate"`       // discount on this line
+	Category    string      `json:"category,omitempty"` // tax category; empty for none
+}
+
+// Invoice is a list of line items with discounts and tax categories.
+type Invoice struct {
+	Number     string
+	Date       time.Time
+	Lines      []Line
+	Discount   money.Rate                      // discount on the whole invoice, applied after line discounts
+	Categories map[string][]money.TaxComponent // taxes for each category
+	Rounding   money.Rounding
+	Strategy   Strategy
+	Formatter  money.Formatter // used by Summary.Text
+}
+
+// Option function.
+type Option func(*Invoice)
+
+// Date sets the invoice date.
+func Date(t time.Time) Option {
+	return func(inv *Invoice) {
+		inv.Date = t
+	}
+}
+
+// Discount sets the discount on the whole invoice.
+func Discount(r money.Rate) Option {
+	return func(inv *Invoice) {
+		inv.Discount = r
+	}
+}
+
+// TaxCategory defines the taxes levied on lines in a category.
+func TaxCategory(name string, taxes ...money.TaxComponent) Option {
+	return func(inv *Invoice) {
+		inv.Categories[name] = taxes
+	}
+}
+
+// Rounding sets the rounding mode for discounts and taxes.
+func Rounding(mode money.Rounding) Option {
+	return func(inv *Invoice) {
+		inv.Rounding = mode
+	}
+}
+
+// RoundPerTotal rounds tax once per category total rather than on each line.
+func RoundPerTotal() Option {
+	return func(inv *Invoice) {
+		inv.Strategy = PerTotal
+	}
+}
+
+// Format sets the formatter used for text output.
+func Format(f money.Formatter) Option {
+	return func(inv *Invoice) {
+		inv.Formatter = f
+	}
+}
+
+// New returns an empty invoice.
+func New(number string, opts ...Option) *Invoice {
+	inv := &Invoice{
+		Number:     number,
+		Date:       time.Time{},
+		Lines:      nil,
+		Discount:   0,
+		Categories: map[string][]money.TaxComponent{},
+		Rounding:   money.RoundHalfUp,
+		Strategy:   PerLine,
+		Formatter:  money.Formatter{Locale: money.LocaleUS, Accounting: false, UseCode: false},
+	}
+	for _, opt := range opts {
+		opt(inv)
+	}
+	return inv
+}
+
+// Add appends lines to the invoice.
+func (inv *Invoice) Add(lines ...Line) *Invoice {
+	inv.Lines = append(inv.Lines, lines...)
+	return inv
+}
+
+// Summary calculates the invoice totals.
+// Every total reconciles to the cent: the line totals sum to the invoice total and the
+// category taxes sum to the invoice tax.
+func (inv *Invoice) Summary() (Summary, error) {
+	s := Summary{
+		Number:    inv.Number,
+		Date:      inv.Date,
+		Lines:     make([]LineTotal, len(inv.Lines)),
+		Subtotal:  0,
+		Discount:  0,
+		Net:       0,
+		Taxes:     nil,
+		Tax:       0,
+		Total:     0,
+		formatter: inv.Formatter,
+	}
+	for i, l := range inv.Lines {
+		lt, err := inv.line(l)
+		if err != nil {
+			return Summary{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		s.Lines[i] = lt
+	}
+	if err := inv.discount(&s); err != nil {
+		return Summary{}, err
+	}
+	var err error
+	if inv.Strategy == PerTotal {
+		err = inv.taxPerTotal(&s)
+	} else {
+		err = inv.taxPerLine(&s)
+	}
+	if err != nil {
+		return Summary{}, err
+	}
+	if err := s.total(); err != nil {
+		return Summary{}, err
+	}
+	return s, nil
+}
+
+// line calculates the gross and line discount of a line.
+func (inv *Invoice) line(l Line) (LineTotal, error) {
+	if l.Quantity < 1 {
+		return LineTotal{}, fmt.Errorf("%w: %d", ErrInvalidQuantity, l.Quantity)
+	}
+	if _, ok := inv.Categories[l.Category]; !ok && l.Category != "" {
+		return LineTotal{}, fmt.Errorf("%w: %q", ErrUnknownCategory, l.Category)
+	}
+	gross, err := l.UnitPrice.MulInt(l.Quantity)
+	if err != nil {
+		return LineTotal{}, err
+	}
+	discount := gross.Discount(l.Discount, inv.Rounding)
+	return LineTotal{
+		Line:            l,
+		Gross:           gross,
+		Discount:        discount,
+		InvoiceDiscount: 0,
+		Net:             gross - discount,
+		Taxes:           nil,
+		Tax:             0,
+		Total:           0,
+	}, nil
+}
+
+// discount allocates the invoice discount across the lines in proportion to their net amounts.
+func (inv *Invoice) discount(s *Summary) error {
+	var net money.Money
+	weights := make([]money.Money, len(s.Lines))
+	for i, lt := range s.Lines {
+		var err error
+		if net, err = net.Add(lt.Net); err != nil {
+			return err
+		}
+		weights[i] = lt.Net
+	}
+	discount := net.Discount(inv.Discount, inv.Rounding)
+	if discount == 0 {
+		return nil
+	}
+	parts, err := allocate(discount, weights, inv.Rounding)
+	if err != nil {
+		return fmt.Errorf("invoice discount: %w", err)
+	}
+	for i := range s.Lines {
+		s.Lines[i].InvoiceDiscount = parts[i]
+		s.Lines[i].Net -= parts[i]
+	}
+	return nil
+}
+
+func (inv *Invoice) taxPerLine(s *Summary) error {
+	for i := range s.Lines {
+		lt := &s.Lines[i]
+		b, err := lt.Net.ApplyTaxes(inv.Categories[lt.Category], inv.Rounding)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		lt.Tax = b.Tax
+		lt.Taxes = b.Lines
+	}
+	return nil
+}
+
+func (inv *Invoice) taxPerTotal(s *Summary) error {
+	for _, category := range slices.Sorted(maps.Keys(inv.Categories)) {
+		var base money.Money
+		var members []int
+		var weights []money.Money
+		for i, lt := range s.Lines {
+			if lt.Category != category {
+				continue
+			}
+			var err error
+			if base, err = base.Add(lt.Net); err != nil {
+				return err
+			}
+			members = append(members, i)
+			weights = append(weights, lt.Net)
+		}
+		if len(members) == 0 {
+			continue
+		}
+		b, err := base.ApplyTaxes(inv.Categories[category], inv.Rounding)
+		if err != nil {
+			return fmt.Errorf("category %q: %w", category, err)
+		}
+		for _, tax := range b.Lines {
+			parts := make([]money.Money, len(members))
+			if tax.Amount != 0 {
+				if parts, err = allocate(tax.Amount, weights, inv.Rounding); err != nil {
+					return fmt.Errorf("category %q: %w", category, err)
+				}
+			}
+			for j, i := range members {
+				s.Lines[i].Taxes = append(s.Lines[i].Taxes, money.TaxLine{
+					Name:   tax.Name,
+					Rate:   tax.Rate,
+					Amount: parts[j],
+				})
+				s.Lines[i].Tax += parts[j]
+			}
+		}
+	}
+	return nil
+}
+
+// allocate divides total, a proportion of the sum of weights, in proportion to weights, which may be
+// negative for credit lines. Total is first split between the debit and credit lines in proportion to
+// their sums, rounded with mode, and each share is then allocated by absolute weight, so that a credit
+// line receives a part with the opposite sign and the parts always sum to total.
+func allocate(total money.Money, weights []money.Money, mode money.Rounding) ([]money.Money, error) {
+	var debit, credit money.Money
+	debits := make([]int64, len(weights))
+	credits := make([]int64, len(weights))
+	for i, w := range weights {
+		if w > 0 {
+			debit += w
+			debits[i] = int64(w)
+		} else {
+			credit += w
+			credits[i] = -int64(w)
+		}
+	}
+	if credit == 0 {
+		return total.Allocate(debits...)
+	}
+	if debit+credit == 0 {
+		return nil, fmt.Errorf("%w: lines sum to zero", money.ErrInvalidRatio)
+	}
+	share, err := total.MulRat(big.NewRat(int64(debit), int64(debit+credit)), mode)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]money.Money, len(weights))
+	for _, side := range []struct {
+		amount money.Money
+		ratios []int64
+	}{
+		{share, debits},
+		{total - share, credits},
+	} {
+		if side.amount == 0 {
+			continue
+		}
+		p, err := side.amount.Allocate(side.ratios...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range parts {
+			parts[i] += p[i]
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,103 @@
+package invoice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"github.com/mattkasun/tools/money/invoice"
+)
+
+var (
+	gst = money.TaxComponent{Name: "GST", Rate: 5 * money.Percent}
+	pst = money.TaxComponent{Name: "PST", Rate: 7 * money.Percent}
+)
+
+func coffee(opts ...invoice.Option) *invoice.Invoice {
+	opts = append([]invoice.Option{
+		invoice.Date(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)),
+		invoice.TaxCategory("standard", gst, pst),
+		invoice.TaxCategory("food", gst),
+	}, opts...)
+	return invoice.New("INV-1", opts...).Add(
+		invoice.Line{Description: "Coffee", Quantity: 3, UnitPrice: 333, Category: "food"},
+		invoice.Line{Description: "Mug", Quantity: 1, UnitPrice: 1099, Discount: 10 * money.Percent, Category: "standard"},
+		invoice.Line{Description: "Gift card", Quantity: 1, UnitPrice: 2500},
+	)
+}
+
+func reconcile(t *testing.T, s invoice.Summary) {
+	t.Helper()
+	var net, tax, total, taxes money.Money
+	for _, l := range s.Lines {
+		should.BeEqual(t, l.Gross-l.Discount-l.InvoiceDiscount, l.Net)
+		should.BeEqual(t, l.Net+l.Tax, l.Total)
+		net += l.Net
+		tax += l.Tax
+		total += l.Total
+	}
+	for _, tt := range s.Taxes {
+		taxes += tt.Amount
+	}
+	should.BeEqual(t, net, s.Net)
+	should.BeEqual(t, tax, s.Tax)
+	should.BeEqual(t, taxes, s.Tax)
+	should.BeEqual(t, total, s.Total)
+	should.BeEqual(t, s.Subtotal-s.Discount, s.Net)
+	should.BeEqual(t, s.Net+s.Tax, s.Total)
+}
+
+func TestPerLine(t *testing.T) {
+	s, err := coffee().Summary()
+	should.NotBeError(t, err)
+	reconcile(t, s)
+	should.BeEqual(t, s.Subtotal, money.Money(4598))
+	should.BeEqual(t, s.Lines[1].Discount, money.Money(110))
+	should.BeEqual(t, s.Lines[0].Tax, money.Money(50))  // 5% of 9.99
+	should.BeEqual(t, s.Lines[1].Tax, money.Money(118)) // 5% + 7% of 9.89
+	should.BeEqual(t, s.Lines[2].Tax, money.Money(0))
+	should.BeEqual(t, s.Total, money.Money(4656))
+	should.HaveLength(t, s.Taxes, 2)
+	should.BeEqual(t, s.Taxes[0].Amount, money.Money(99))
+}
+
+func TestPerTotal(t *testing.T) {
+	inv := coffee(invoice.RoundPerTotal(), invoice.Discount(10*money.Percent), invoice.Rounding(money.RoundHalfEven))
+	inv.Add(invoice.Line{Description: "Coffee", Quantity: 1, UnitPrice: 111, Category: "food"})
+	s, err := inv.Summary()
+	should.NotBeError(t, err)
+	reconcile(t, s)
+	should.BeEqual(t, s.Discount, money.Money(110+460))
+}
+
+func TestErrors(t *testing.T) {
+	_, err := invoice.New("1").Add(invoice.Line{Description: "x", Quantity: 0, UnitPrice: 1}).Summary()
+	should.BeErrorIs(t, err, invoice.ErrInvalidQuantity)
+	_, err = invoice.New("1").Add(invoice.Line{Description: "x", Quantity: 1, UnitPrice: 1, Category: "lux"}).Summary()
+	should.BeErrorIs(t, err, invoice.ErrUnknownCategory)
+	_, err = invoice.New("1").Add(invoice.Line{Description: "x", Quantity: 2, UnitPrice: 1e16}).Summary()
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	free := invoice.Line{Description: "x", Quantity: 1, UnitPrice: 9e15, Discount: money.Whole}
+	s, err := invoice.New("1").Add(free, free).Summary() // the subtotal overflows, though the net is zero
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	should.BeEmpty(t, s.Lines)
+}
+
+func TestCreditLine(t *testing.T) {
+	for _, strategy := range []invoice.Option{invoice.RoundPerTotal(), invoice.Rounding(money.RoundHalfUp)} {
+		inv := invoice.New("CR-1", invoice.Discount(10*money.Percent), invoice.TaxCategory("standard", gst), strategy).Add(
+			invoice.Line{Description: "Widget", Quantity: 1, UnitPrice: 10000, Category: "standard"},
+			invoice.Line{Description: "Return", Quantity: 1, UnitPrice: -2000, Category: "standard"},
+		)
+		s, err := inv.Summary()
+		should.NotBeError(t, err)
+		reconcile(t, s)
+		should.BeEqual(t, s.Discount, money.Money(800))
+		should.BeEqual(t, s.Lines[0].InvoiceDiscount, money.Money(1000))
+		should.BeEqual(t, s.Lines[1].InvoiceDiscount, money.Money(-200))
+		should.BeEqual(t, s.Lines[0].Tax, money.Money(450))
+		should.BeEqual(t, s.Lines[1].Tax, money.Money(-90))
+		should.BeEqual(t, s.Total, money.Money(7560))
+	}
+}
@@ -0,0 +1,128 @@
+package invoice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+// LineTotal is a line with its discounts and taxes; Gross - Discount - InvoiceDiscount is Net,
+// and Net + Tax is Total.
+type LineTotal struct {
+	Line            `json:"line"`
+	Gross           money.Money     `json:"gross"`
+	Discount        money.Money     `json:"discount"`
+	InvoiceDiscount money.Money     `json:"invoiceDiscount"`
+	Net             money.Money     `json:"net"`
+	Taxes           []money.TaxLine `json:"taxes"`
+	Tax             money.Money     `json:"tax"`
+	Total           money.Money     `json:"total"`
+}
+
+// TaxTotal is the total of one tax across the invoice.
+type TaxTotal struct {
+	Name   string      `json:"name"`
+	Rate   money.Rate  `json:"rate"`
+	Amount money.Money `json:"amount"`
+}
+
+// Summary is a calculated invoice.
+type Summary struct {
+	Number    string      `json:"number"`
+	Date      time.Time   `json:"date"`
+	Lines     []LineTotal `json:"lines"`
+	Subtotal  money.Money `json:"subtotal"` // sum of line gross amounts
+	Discount  money.Money `json:"discount"` // line and invoice discounts
+	Net       money.Money `json:"net"`
+	Taxes     []TaxTotal  `json:"taxes"`
+	Tax       money.Money `json:"tax"`
+	Total     money.Money `json:"total"`
+	formatter money.Formatter
+}
+
+// WriteJSON writes the summary as indented JSON.
+func (s Summary) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("encode invoice %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the summary as a plain text table.
+func (s Summary) WriteText(w io.Writer) error {
+	f := s.formatter
+	if _, err := fmt.Fprintf(w, "Invoice %s  %s\n\n", s.Number, s.Date.Format(time.DateOnly)); err != nil {
+		return fmt.Errorf("write invoice %w", err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight) //nolint:mnd
+	fmt.Fprintln(tw, "Description\tQty\tPrice\tDiscount\tTax\tTotal\t")
+	for _, l := range s.Lines {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t\n", l.Description, l.Quantity, f.Format(l.UnitPrice),
+			f.Format(l.Discount+l.InvoiceDiscount), f.Format(l.Tax), f.Format(l.Total))
+	}
+	total := func(label string, m money.Money) {
+		fmt.Fprintf(tw, "\t\t\t\t%s\t%s\t\n", label, f.Format(m))
+	}
+	total("Subtotal", s.Subtotal)
+	if s.Discount != 0 {
+		total("Discount", -s.Discount)
+	}
+	total("Net", s.Net)
+	for _, t := range s.Taxes {
+		total(strings.TrimSpace(t.Name+" "+t.Rate.String()), t.Amount)
+	}
+	total("Total", s.Total)
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write invoice %w", err)
+	}
+	return nil
+}
+
+// Text returns the summary as a plain text table.
+func (s Summary) Text() string {
+	var b strings.Builder
+	_ = s.WriteText(&b)
+	return b.String()
+}
+
+// total sums the lines and taxes, checking for overflow.
+func (s *Summary) total() error {
+	index := map[string]int{}
+	for i := range s.Lines {
+		l := &s.Lines[i]
+		l.Total = l.Net + l.Tax
+		for _, m := range []struct {
+			sum *money.Money
+			add money.Money
+		}{
+			{&s.Subtotal, l.Gross},
+			{&s.Discount, l.Discount + l.InvoiceDiscount},
+			{&s.Net, l.Net},
+			{&s.Tax, l.Tax},
+			{&s.Total, l.Total},
+		} {
+			var err error
+			if *m.sum, err = m.sum.Add(m.add); err != nil {
+				return fmt.Errorf("invoice total: %w", err)
+			}
+		}
+		for _, t := range l.Taxes {
+			key := t.Name + "\x00" + t.Rate.String()
+			j, ok := index[key]
+			if !ok {
+				j = len(s.Taxes)
+				index[key] = j
+				s.Taxes = append(s.Taxes, TaxTotal{Name: t.Name, Rate: t.Rate, Amount: 0})
+			}
+			s.Taxes[j].Amount += t.Amount
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package invoice_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"github.com/mattkasun/tools/money/invoice"
+)
+
+func TestText(t *testing.T) {
+	s, err := coffee().Summary()
+	should.NotBeError(t, err)
+	text := s.Text()
+	should.ContainSubstring(t, text, "Invoice INV-1")
+	should.ContainSubstring(t, text, "2025-01-02")
+	should.ContainSubstring(t, text, "GST 5%")
+	should.ContainSubstring(t, text, "$46.56")
+
+	s, err = coffee(invoice.Format(money.Formatter{Locale: money.LocaleDE})).Summary()
+	should.NotBeError(t, err)
+	should.ContainSubstring(t, s.Text(), "46,56 €")
+}
+
+func TestJSON(t *testing.T) {
+	s, err := coffee().Summary()
+	should.NotBeError(t, err)
+	var buf bytes.Buffer
+	should.NotBeError(t, s.WriteJSON(&buf))
+	var out struct {
+		Total money.Money `json:"total"`
+		Lines []struct {
+			Line struct {
+				Description string `json:"description"`
+			} `json:"line"`
+			Taxes []struct {
+				Rate money.Rate `json:"rate"`
+			} `json:"taxes"`
+		} `json:"lines"`
+	}
+	should.NotBeError(t, json.Unmarshal(buf.Bytes(), &out))
+	should.BeEqual(t, out.Total, s.Total)
+	should.BeEqual(t, out.Lines[1].Line.Description, "Mug")
+	should.BeEqual(t, out.Lines[1].Taxes[1].Rate, 7*money.Percent)
+	should.ContainSubstring(t, buf.String(), `"total": "46.56"`)
+}
@@ -0,0 +1,407 @@
+// Package ledger implements double-entry bookkeeping on money.Money.
+//
+// Postings are debits when positive and credits when negative, and the postings of
+// every entry must sum to zero. A Ledger may be persisted to an append-only journal file.
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mattkasun/tools/money"
+)
+
+var (
+	// ErrUnbalanced is returned when the postings of an entry do not sum to zero.
+	ErrUnbalanced = errors.New("unbalanced entry")
+	// ErrUnknownAccount is returned when posting to an account that has not been opened.
+	ErrUnknownAccount = errors.New("unknown account")
+	// ErrDuplicateAccount is returned when opening an account that already exists.
+	ErrDuplicateAccount = errors.New("duplicate account")
+	// ErrInvalidEntry is returned when an entry has fewer than two postings.
+	ErrInvalidEntry = errors.New("invalid entry")
+	// ErrCorruptJournal is returned when a journal file cannot be replayed.
+	ErrCorruptJournal = errors.New("corrupt journal")
+	// ErrJournalFailed is returned once a failed journal write could not be rolled back;
+	// the ledger refuses further changes, as the file may end with a partial record.
+	ErrJournalFailed = errors.New("journal failed")
+	// ErrClosed is returned when opening an account or posting an entry after Close.
+	ErrClosed = errors.New("ledger closed")
+
+	errRecord = errors.New("expected one account or entry")
+)
+
+// AccountType classifies an account.
+type AccountType string
+
+// Account types.
+const (
+	Asset     AccountType = "asset"
+	Liability AccountType = "liability"
+	Equity    AccountType = "equity"
+	Income    AccountType = "income"
+	Expense   AccountType = "expense"
+)
+
+// Account is a named account in the ledger.
+type Account struct {
+	Name string      `json:"name"`
+	Type AccountType `json:"type"`
+}
+
+// Posting is a debit (positive) or credit (negative) to an account.
+type Posting struct {
+	Account string      `json:"account"`
+	Amount  money.Money `json:"amount"`
+}
+
+// Entry is a journal entry whose postings sum to zero.
+type Entry struct {
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings"`
+}
+
+// UnbalancedError records an entry whose postings do not sum to zero.
+type UnbalancedError struct {
+	Description string
+	Sum         money.Money
+}
+
+// Error implements the error interface.
+func (e *UnbalancedError) Error() string {
+	return fmt.Sprintf("%s: %q is out by %s", ErrUnbalanced, e.Description, e.Sum)
+}
+
+// Unwrap returns ErrUnbalanced.
+func (e *UnbalancedError) Unwrap() error {
+	return ErrUnbalanced
+}
+
+// StatementLine is an entry's effect on one account and the running balance after it.
+type StatementLine struct {
+	Date        time.Time
+	Description string
+	Amount      money.Money
+	Balance     money.Money
+}
+
+// Ledger holds accounts, entries and balances; it is safe for concurrent use.
+type Ledger struct {
+	mu       sync.RWMutex
+	accounts map[string]Account
+	order    []string // account names in the order opened
+	balances map[string]money.Money
+	entries  []Entry
+	journal  journal
+	offset   int64 // journal size after the last complete record
+	failed   error // set when a failed write could not be rolled back
+	closed   bool
+}
+
+// journal is the file a Ledger appends records to.
+type journal interface {
+	io.WriteCloser
+	Truncate(size int64) error
+	Sync() error
+}
+
+// record is a line in a journal file; exactly one of Account and Entry is set.
+type record struct {
+	Account *Account `json:"account,omitempty"`
+	Entry   *Entry   `json:"entry,omitempty"`
+}
+
+// New returns an empty in memory Ledger.
+func New() *Ledger {
+	return &Ledger{
+		mu:       sync.RWMutex{},
+		accounts: map[string]Account{},
+		order:    nil,
+		balances: map[string]money.Money{},
+		entries:  nil,
+		journal:  nil,
+		offset:   0,
+		failed:   nil,
+		closed:   false,
+	}
+}
+
+// Open returns a Ledger backed by the journal file at path, replaying any existing records.
+// New accounts and entries are appended to the file, one JSON record per line, and synced to disk.
+// A final line without a newline, left by a crash during a write, was never acknowledged and is
+// truncated away.
+func Open(path string) (*Ledger, error) {
+	l := New()
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("open journal %w", err)
+	}
+	offset, err := l.replay(file)
+	if err == nil {
+		err = truncateTail(file, offset)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	l.journal = file
+	l.offset = offset
+	return l, nil
+}
+
+// truncateTail removes anything after offset from the journal file.
+func truncateTail(file *os.File, offset int64) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat journal %w", err)
+	}
+	if info.Size() == offset {
+		return nil
+	}
+	if err := file.Truncate(offset); err != nil {
+		return fmt.Errorf("truncate journal %w", err)
+	}
+	return nil
+}
+
+// Close closes the journal file, if any; later changes to the ledger fail with ErrClosed.
+func (l *Ledger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	if l.journal == nil {
+		return nil
+	}
+	err := l.journal.Close()
+	l.journal = nil
+	if err != nil {
+		return fmt.Errorf("close journal %w", err)
+	}
+	return nil
+}
+
+// OpenAccount adds an account to the ledger.
+func (l *Ledger) OpenAccount(a Account) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.checkAccount(a); err != nil {
+		return err
+	}
+	if err := l.write(record{Account: &a, Entry: nil}); err != nil {
+		return err
+	}
+	l.openAccount(a)
+	return nil
+}
+
+// Post validates an entry and applies it to the account balances.
+// Nothing is recorded unless the entry balances and no balance would overflow.
+func (l *Ledger) Post(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	balances, err := l.apply(e)
+	if err != nil {
+		return err
+	}
+	if err := l.write(record{Account: nil, Entry: &e}); err != nil {
+		return err
+	}
+	l.commit(e, balances)
+	return nil
+}
+
+// Balance returns the balance of an account.
+func (l *Ledger) Balance(account string) (money.Money, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if _, ok := l.accounts[account]; !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownAccount, account)
+	}
+	return l.balances[account], nil
+}
+
+// Accounts returns the accounts in the order they were opened.
+func (l *Ledger) Accounts() []Account {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	accounts := make([]Account, 0, len(l.order))
+	for _, name := range l.order {
+		accounts = append(accounts, l.accounts[name])
+	}
+	return accounts
+}
+
+// Entries returns a copy of the posted entries in order.
+func (l *Ledger) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	entries := make([]Entry, len(l.entries))
+	for i, e := range l.entries {
+		e.Postings = slices.Clone(e.Postings)
+		entries[i] = e
+	}
+	return entries
+}
+
+// Statement returns each posting to an account with the running balance after it.
+func (l *Ledger) Statement(account string) ([]StatementLine, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if _, ok := l.accounts[account]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAccount, account)
+	}
+	var lines []StatementLine
+	var balance money.Money
+	for _, e := range l.entries {
+		for _, p := range e.Postings {
+			if p.Account != account {
+				continue
+			}
+			// balances were overflow checked when the entry was posted
+			balance += p.Amount
+			lines = append(lines, StatementLine{
+				Date:        e.Date,
+				Description: e.Description,
+				Amount:      p.Amount,
+				Balance:     balance,
+			})
+		}
+	}
+	return lines, nil
+}
+
+func (l *Ledger) checkAccount(a Account) error {
+	if a.Name == "" {
+		return fmt.Errorf("%w: empty name", ErrUnknownAccount)
+	}
+	if _, ok := l.accounts[a.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateAccount, a.Name)
+	}
+	return nil
+}
+
+func (l *Ledger) openAccount(a Account) {
+	l.accounts[a.Name] = a
+	l.order = append(l.order, a.Name)
+}
+
+// apply validates e and returns the new balances of the accounts it posts to.
+func (l *Ledger) apply(e Entry) (map[string]money.Money, error) {
+	if len(e.Postings) < 2 { //nolint:mnd
+		return nil, fmt.Errorf("%w: %q has %d postings", ErrInvalidEntry, e.Description, len(e.Postings))
+	}
+	var sum money.Money
+	balances := map[string]money.Money{}
+	for _, p := range e.Postings {
+		if _, ok := l.accounts[p.Account]; !ok {
+			return nil, fmt.Errorf("%w: %q in %q", ErrUnknownAccount, p.Account, e.Description)
+		}
+		var err error
+		if sum, err = sum.Add(p.Amount); err != nil {
+			return nil, fmt.Errorf("%q: %w", e.Description, err)
+		}
+		balance, ok := balances[p.Account]
+		if !ok {
+			balance = l.balances[p.Account]
+		}
+		if balances[p.Account], err = balance.Add(p.Amount); err != nil {
+			return nil, fmt.Errorf("%q: account %q: %w", e.Description, p.Account, err)
+		}
+	}
+	if sum != 0 {
+		return nil, &UnbalancedError{Description: e.Description, Sum: sum}
+	}
+	return balances, nil
+}
+
+func (l *Ledger) commit(e Entry, balances map[string]money.Money) {
+	e.Postings = slices.Clone(e.Postings)
+	l.entries = append(l.entries, e)
+	for account, balance := range balances {
+		l.balances[account] = balance
+	}
+}
+
+// write appends a record to the journal, if any, and syncs it.
+// If the write fails the journal is truncated back to the last complete record;
+// if that fails too the ledger is marked as failed.
+func (l *Ledger) write(r record) error {
+	if l.closed {
+		return ErrClosed
+	}
+	if l.failed != nil {
+		return l.failed
+	}
+	if l.journal == nil {
+		return nil
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encode journal record %w", err)
+	}
+	line = append(line, '\n')
+	_, err = l.journal.Write(line)
+	if err == nil {
+		err = l.journal.Sync()
+	}
+	if err != nil {
+		if terr := l.journal.Truncate(l.offset); terr != nil {
+			l.failed = fmt.Errorf("%w: %w", ErrJournalFailed, terr)
+			return fmt.Errorf("write journal %w: %w", err, l.failed)
+		}
+		return fmt.Errorf("write journal %w", err)
+	}
+	l.offset += int64(len(line))
+	return nil
+}
+
+// replay applies each record in a journal, enforcing the same invariants as OpenAccount and Post,
+// and returns the size of the complete records. A final line without a newline is ignored.
+func (l *Ledger) replay(r io.Reader) (int64, error) {
+	reader := bufio.NewReader(r)
+	var offset int64
+	for n := 1; ; n++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return offset, nil // any partial line is a torn write
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read journal %w", err)
+		}
+		if err := l.replayRecord(line); err != nil {
+			return 0, fmt.Errorf("%w: line %d: %w", ErrCorruptJournal, n, err)
+		}
+		offset += int64(len(line))
+	}
+}
+
+func (l *Ledger) replayRecord(line []byte) error {
+	var rec record
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return err //nolint:wrapcheck
+	}
+	switch {
+	case rec.Account != nil && rec.Entry == nil:
+		if err := l.checkAccount(*rec.Account); err != nil {
+			return err
+		}
+		l.openAccount(*rec.Account)
+	case rec.Entry != nil && rec.Account == nil:
+		balances, err := l.apply(*rec.Entry)
+		if err != nil {
+			return err
+		}
+		l.commit(*rec.Entry, balances)
+	default:
+		return errRecord
+	}
+	return nil
+}
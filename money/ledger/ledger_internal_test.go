@@ -0,0 +1,65 @@
+package ledger //nolint:testpackage
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+)
+
+var errDisk = errors.New("disk full")
+
+// flakyJournal writes part of each record and fails while failWrite is set.
+type flakyJournal struct {
+	bytes.Buffer
+
+	failWrite    bool
+	failTruncate bool
+}
+
+func (j *flakyJournal) Write(p []byte) (int, error) {
+	if j.failWrite {
+		n, _ := j.Buffer.Write(p[:len(p)/2])
+		return n, errDisk
+	}
+	return j.Buffer.Write(p)
+}
+
+func (j *flakyJournal) Truncate(size int64) error {
+	if j.failTruncate {
+		return errDisk
+	}
+	j.Buffer.Truncate(int(size))
+	return nil
+}
+
+func (j *flakyJournal) Sync() error  { return nil }
+func (j *flakyJournal) Close() error { return nil }
+
+func TestWriteRollsBack(t *testing.T) {
+	j := &flakyJournal{}
+	l := New()
+	l.journal = j
+	should.NotBeError(t, l.OpenAccount(Account{Name: "cash", Type: Asset}))
+	should.NotBeError(t, l.OpenAccount(Account{Name: "sales", Type: Income}))
+	good := j.Len()
+
+	j.failWrite = true
+	should.BeErrorIs(t, l.OpenAccount(Account{Name: "rent", Type: Expense}), errDisk)
+	should.BeEqual(t, j.Len(), good)
+	should.HaveLength(t, l.Accounts(), 2)
+
+	j.failWrite = false
+	should.NotBeError(t, l.OpenAccount(Account{Name: "rent", Type: Expense}))
+	replayed := New()
+	_, err := replayed.replay(bytes.NewReader(j.Bytes()))
+	should.NotBeError(t, err)
+	should.HaveLength(t, replayed.Accounts(), 3)
+
+	j.failWrite, j.failTruncate = true, true
+	should.BeErrorIs(t, l.OpenAccount(Account{Name: "tax", Type: Liability}), ErrJournalFailed)
+	j.failWrite, j.failTruncate = false, false
+	should.BeErrorIs(t, l.OpenAccount(Account{Name: "tax", Type: Liability}), ErrJournalFailed)
+	should.HaveLength(t, l.Accounts(), 3)
+}
@@ -0,0 +1,186 @@
+package ledger_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"github.com/mattkasun/tools/money/ledger"
+)
+
+func books(t *testing.T, l *ledger.Ledger) {
+	t.Helper()
+	for _, a := range []ledger.Account{
+		{Name: "cash", Type: ledger.Asset},
+		{Name: "sales", Type: ledger.Income},
+		{Name: "rent", Type: ledger.Expense},
+	} {
+		should.NotBeError(t, l.OpenAccount(a))
+	}
+}
+
+func entry(desc string, postings ...ledger.Posting) ledger.Entry {
+	return ledger.Entry{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Description: desc, Postings: postings}
+}
+
+func TestPost(t *testing.T) {
+	l := ledger.New()
+	books(t, l)
+	should.NotBeError(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 10000},
+		ledger.Posting{Account: "sales", Amount: -10000},
+	)))
+	should.NotBeError(t, l.Post(entry("rent",
+		ledger.Posting{Account: "rent", Amount: 2500},
+		ledger.Posting{Account: "cash", Amount: -2500},
+	)))
+	cash, err := l.Balance("cash")
+	should.NotBeError(t, err)
+	should.BeEqual(t, cash, money.Money(7500))
+
+	statement, err := l.Statement("cash")
+	should.NotBeError(t, err)
+	should.HaveLength(t, statement, 2)
+	should.BeEqual(t, statement[1].Balance, money.Money(7500))
+	should.HaveLength(t, l.Entries(), 2)
+	should.HaveLength(t, l.Accounts(), 3)
+}
+
+func TestPostErrors(t *testing.T) {
+	l := ledger.New()
+	books(t, l)
+	err := l.Post(entry("short",
+		ledger.Posting{Account: "cash", Amount: 100},
+		ledger.Posting{Account: "sales", Amount: -99},
+	))
+	should.BeErrorIs(t, err, ledger.ErrUnbalanced)
+	var unbalanced *ledger.UnbalancedError
+	should.BeErrorAs(t, err, &unbalanced)
+	should.BeEqual(t, unbalanced.Sum, money.Money(1))
+
+	err = l.Post(entry("unknown",
+		ledger.Posting{Account: "cash", Amount: 100},
+		ledger.Posting{Account: "bank", Amount: -100},
+	))
+	should.BeErrorIs(t, err, ledger.ErrUnknownAccount)
+	should.BeErrorIs(t, l.Post(entry("single", ledger.Posting{Account: "cash", Amount: 0})), ledger.ErrInvalidEntry)
+	err = l.Post(entry("huge",
+		ledger.Posting{Account: "cash", Amount: 1e16},
+		ledger.Posting{Account: "cash", Amount: 1},
+		ledger.Posting{Account: "sales", Amount: -1e16 - 1},
+	))
+	should.BeErrorIs(t, err, money.ErrOverflow)
+	should.BeErrorIs(t, l.OpenAccount(ledger.Account{Name: "cash", Type: ledger.Asset}), ledger.ErrDuplicateAccount)
+	_, err = l.Balance("bank")
+	should.BeErrorIs(t, err, ledger.ErrUnknownAccount)
+
+	cash, err := l.Balance("cash")
+	should.NotBeError(t, err)
+	should.BeEqual(t, cash, money.Money(0))
+	should.BeEmpty(t, l.Entries())
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	l, err := ledger.Open(path)
+	should.NotBeError(t, err)
+	books(t, l)
+	should.NotBeError(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 10000},
+		ledger.Posting{Account: "sales", Amount: -10000},
+	)))
+	should.BeError(t, l.Post(entry("bad",
+		ledger.Posting{Account: "cash", Amount: 1},
+		ledger.Posting{Account: "sales", Amount: 1},
+	)))
+	should.NotBeError(t, l.Close())
+
+	l, err = ledger.Open(path)
+	should.NotBeError(t, err)
+	cash, err := l.Balance("cash")
+	should.NotBeError(t, err)
+	should.BeEqual(t, cash, money.Money(10000))
+	should.HaveLength(t, l.Entries(), 1)
+	should.NotBeError(t, l.Close())
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	should.NotBeError(t, err)
+	_, err = file.WriteString(`{"entry":{"description":"forged","postings":[{"account":"cash","amount":"1.00"},` +
+		`{"account":"sales","amount":"0"}]}}` + "\n")
+	should.NotBeError(t, err)
+	should.NotBeError(t, file.Close())
+	_, err = ledger.Open(path)
+	should.BeErrorIs(t, err, ledger.ErrCorruptJournal)
+	should.BeErrorIs(t, err, ledger.ErrUnbalanced)
+}
+
+func TestJournalTornWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	l, err := ledger.Open(path)
+	should.NotBeError(t, err)
+	books(t, l)
+	should.NotBeError(t, l.Close())
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	should.NotBeError(t, err)
+	_, err = file.WriteString(`{"entry":{"description":"torn","postings":[{"acc`)
+	should.NotBeError(t, err)
+	should.NotBeError(t, file.Close())
+
+	l, err = ledger.Open(path)
+	should.NotBeError(t, err)
+	should.HaveLength(t, l.Accounts(), 3)
+	should.HaveLength(t, l.Entries(), 0)
+	should.NotBeError(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 500},
+		ledger.Posting{Account: "sales", Amount: -500},
+	)))
+	should.NotBeError(t, l.Close())
+
+	l, err = ledger.Open(path)
+	should.NotBeError(t, err)
+	should.HaveLength(t, l.Entries(), 1)
+	should.NotBeError(t, l.Close())
+}
+
+func TestEntriesCopy(t *testing.T) {
+	l := ledger.New()
+	books(t, l)
+	should.NotBeError(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 500},
+		ledger.Posting{Account: "sales", Amount: -500},
+	)))
+	entries := l.Entries()
+	entries[0].Postings[0].Amount = 1
+	entries[0].Postings[0].Account = "rent"
+
+	lines, err := l.Statement("cash")
+	should.NotBeError(t, err)
+	should.HaveLength(t, lines, 1)
+	should.BeEqual(t, lines[0].Amount, money.Money(500))
+	should.BeEqual(t, l.Entries()[0].Postings[0], ledger.Posting{Account: "cash", Amount: 500})
+}
+
+func TestClosed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	l, err := ledger.Open(path)
+	should.NotBeError(t, err)
+	books(t, l)
+	should.NotBeError(t, l.Close())
+	should.BeErrorIs(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 500},
+		ledger.Posting{Account: "sales", Amount: -500},
+	)), ledger.ErrClosed)
+	should.BeErrorIs(t, l.OpenAccount(ledger.Account{Name: "bank", Type: ledger.Asset}), ledger.ErrClosed)
+	should.BeEmpty(t, l.Entries())
+	should.HaveLength(t, l.Accounts(), 3)
+
+	l, err = ledger.Open(path)
+	should.NotBeError(t, err)
+	should.BeEmpty(t, l.Entries())
+	should.HaveLength(t, l.Accounts(), 3)
+	should.NotBeError(t, l.Close())
+}
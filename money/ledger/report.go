@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"github.com/mattkasun/tools/money"
+)
+
+// TrialBalanceRow is an account balance in debit or credit column.
+type TrialBalanceRow struct {
+	Account Account
+	Debit   money.Money
+	Credit  money.Money
+}
+
+// TrialBalance lists every account balance; the debit and credit totals are always equal.
+type TrialBalance struct {
+	Rows   []TrialBalanceRow
+	Debit  money.Money
+	Credit money.Money
+}
+
+// Balanced reports whether total debits equal total credits.
+func (t TrialBalance) Balanced() bool {
+	return t.Debit == t.Credit
+}
+
+// TrialBalance returns the balance of each account in the order opened.
+// Debit balances are shown in the Debit column and credit balances, as positive amounts, in the Credit column.
+func (l *Ledger) TrialBalance() (TrialBalance, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	report := TrialBalance{Rows: make([]TrialBalanceRow, 0, len(l.order)), Debit: 0, Credit: 0}
+	for _, name := range l.order {
+		row := TrialBalanceRow{Account: l.accounts[name], Debit: 0, Credit: 0}
+		var err error
+		switch balance := l.balances[name]; {
+		case balance > 0:
+			row.Debit = balance
+			report.Debit, err = report.Debit.Add(balance)
+		case balance < 0:
+			row.Credit = -balance
+			report.Credit, err = report.Credit.Add(row.Credit)
+		}
+		if err != nil {
+			return TrialBalance{}, err
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	if !report.Balanced() {
+		return report, &UnbalancedError{Description: "trial balance", Sum: report.Debit - report.Credit}
+	}
+	return report, nil
+}
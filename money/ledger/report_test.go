@@ -0,0 +1,29 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"github.com/mattkasun/tools/money/ledger"
+)
+
+func TestTrialBalance(t *testing.T) {
+	l := ledger.New()
+	books(t, l)
+	should.NotBeError(t, l.Post(entry("sale",
+		ledger.Posting{Account: "cash", Amount: 10000},
+		ledger.Posting{Account: "sales", Amount: -10000},
+	)))
+	should.NotBeError(t, l.Post(entry("rent",
+		ledger.Posting{Account: "rent", Amount: 2500},
+		ledger.Posting{Account: "cash", Amount: -2500},
+	)))
+	report, err := l.TrialBalance()
+	should.NotBeError(t, err)
+	should.BeTrue(t, report.Balanced())
+	should.BeEqual(t, report.Debit, money.Money(10000))
+	should.BeEqual(t, report.Rows[0].Debit, money.Money(7500))
+	should.BeEqual(t, report.Rows[1].Credit, money.Money(10000))
+	should.BeEqual(t, report.Rows[2].Debit, money.Money(2500))
+}
@@ -16,16 +16,16 @@ func TestString(t *testing.T) {
 }
 
 func TestTax(t *testing.T) {
-	should.BeEqual(t, money.Money(10000).Tax(0.15), money.Money(1500))
+	should.BeEqual(t, money.Money(10000).Tax(15*money.Percent), money.Money(1500))
 	should.BeEqual(t, money.Money(5000).Tax(0), money.Money(0))
-	should.BeEqual(t, money.Money(-10000).Tax(0.10), money.Money(-1000))
-	should.BeEqual(t, money.Money(999).Tax(0.05), money.Money(50)) // 49.95 → rounds to 50
+	should.BeEqual(t, money.Money(-10000).Tax(10*money.Percent), money.Money(-1000))
+	should.BeEqual(t, money.Money(999).Tax(5*money.Percent), money.Money(50)) // 49.95 → rounds to 50
 }
 
 func TestWithTax(t *testing.T) {
-	should.BeEqual(t, money.New(10000).WithTax(0.15), money.New(11500))
+	should.BeEqual(t, money.New(10000).WithTax(15*money.Percent), money.New(11500))
 	should.BeEqual(t, money.New(20000).WithTax(0), money.New(20000))
-	should.BeEqual(t, money.New(-10000).WithTax(0.10), money.New(-11000))
+	should.BeEqual(t, money.New(-10000).WithTax(10*money.Percent), money.New(-11000))
 }
 
 func TestNew(t *testing.T) {
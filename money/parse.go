@@ -0,0 +1,234 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrSyntax is returned when a money string is malformed.
+	ErrSyntax = errors.New("invalid syntax")
+	// ErrPrecision is returned when a money string has more decimal places than the currency allows.
+	ErrPrecision = errors.New("too many decimal places")
+)
+
+// ParseError records a failure to parse a money string.
+type ParseError struct {
+	Input  string // the string being parsed
+	Offset int    // byte offset of the error in Input
+	Err    error  // the reason, eg ErrSyntax, ErrPrecision or ErrOverflow
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse %q: offset %d: %v: %s", e.Input, e.Offset, e.Err, e.Detail)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses a money string in LocaleUS, accepting the output of Money.String as well as
+// plain forms such as 1234.56, -$5 and (5.00).
+func Parse(s string) (Money, error) {
+	return ParseLocale(s, LocaleUS)
+}
+
+// ParseLocale parses a money string written in loc.
+// The symbol or ISO code may precede or follow the number, the sign may precede or follow the symbol,
+// and negative values may be written in accounting style parentheses.
+// Group separators are optional; at most two decimal places are accepted.
+func ParseLocale(s string, loc Locale) (Money, error) {
+	p := parser{input: s, loc: loc, exponent: centsExponent}
+	return p.parse()
+}
+
+type parser struct {
+	input    string
+	pos      int
+	loc      Locale
+	exponent int // maximum decimal places
+}
+
+func (p *parser) parse() (Money, error) {
+	p.skipSpace()
+	paren := p.consume("(")
+	p.skipSpace()
+	neg, signed := p.sign()
+	p.skipSpace()
+	symbol := p.currency()
+	if symbol {
+		p.skipSpace()
+		if !signed {
+			neg, signed = p.sign()
+		}
+	}
+	start := p.pos
+	whole, frac, err := p.number()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if !symbol && p.currency() {
+		p.skipSpace()
+	}
+	if paren {
+		if signed {
+			return 0, p.errorf(ErrSyntax, "sign inside parentheses")
+		}
+		if !p.consume(")") {
+			return 0, p.errorf(ErrSyntax, "missing closing parenthesis")
+		}
+		neg = true
+		p.skipSpace()
+	}
+	if p.pos < len(p.input) {
+		return 0, p.errorf(ErrSyntax, "unexpected %q", p.input[p.pos:])
+	}
+	cents, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", p.exponent-len(frac)), 10) //nolint:mnd
+	if !ok {
+		return 0, &ParseError{Input: p.input, Offset: start, Err: ErrSyntax, Detail: "invalid number"}
+	}
+	if neg {
+		cents.Neg(cents)
+	}
+	if cents.CmpAbs(bigMax) > 0 {
+		return 0, &ParseError{Input: p.input, Offset: start, Err: ErrOverflow, Detail: "exceeds ceiling"}
+	}
+	return Money(cents.Int64()), nil
+}
+
+// number scans digits with optional group separators and an optional fraction of at most exponent digits.
+func (p *parser) number() (string, string, error) {
+	var whole strings.Builder
+	starts := []int{p.pos} // offsets of the digit groups
+scan:
+	for p.pos < len(p.input) {
+		switch {
+		case isDigit(p.input[p.pos]):
+			whole.WriteByte(p.input[p.pos])
+			p.pos++
+		case p.loc.Group != "" && p.peek(p.loc.Group):
+			if !p.digitAt(p.pos + len(p.loc.Group)) {
+				if strings.TrimSpace(p.loc.Group) == "" {
+					break scan
+				}
+				return "", "", p.errorf(ErrSyntax, "misplaced group separator")
+			}
+			if whole.Len() == 0 {
+				return "", "", p.errorf(ErrSyntax, "misplaced group separator")
+			}
+			p.pos += len(p.loc.Group)
+			starts = append(starts, p.pos)
+		default:
+			break scan
+		}
+	}
+	if err := p.groups(starts, p.pos); err != nil {
+		return "", "", err
+	}
+	return p.fraction(whole.String())
+}
+
+// groups checks the sizes of the digit groups starting at starts and ending at end against
+// the locale's Grouping: the leftmost group may be shorter, the others must be exact.
+func (p *parser) groups(starts []int, end int) error {
+	if len(starts) == 1 {
+		return nil
+	}
+	sizes := p.loc.Grouping
+	if len(sizes) == 0 {
+		sizes = []int{3} //nolint:mnd
+	}
+	for i := len(starts) - 1; i >= 0; i-- {
+		want := sizes[min(len(starts)-1-i, len(sizes)-1)]
+		got := end - starts[i]
+		if got > want || (i > 0 && got < want) {
+			return &ParseError{Input: p.input, Offset: starts[i], Err: ErrSyntax,
+				Detail: fmt.Sprintf("group of %d digits, want %d", got, want)}
+		}
+		end = starts[i] - len(p.loc.Group)
+	}
+	return nil
+}
+
+func (p *parser) fraction(whole string) (string, string, error) {
+	if !p.consume(p.loc.decimal()) {
+		if whole == "" {
+			return "", "", p.errorf(ErrSyntax, "missing digits")
+		}
+		return whole, "", nil
+	}
+	start := p.pos
+	for p.digitAt(p.pos) {
+		p.pos++
+	}
+	frac := p.input[start:p.pos]
+	switch {
+	case whole == "" && frac == "":
+		return "", "", p.errorf(ErrSyntax, "missing digits")
+	case len(frac) > p.exponent:
+		return "", "", &ParseError{Input: p.input, Offset: start + p.exponent, Err: ErrPrecision, Detail: frac}
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	return whole, frac, nil
+}
+
+// currency consumes the locale's currency code or symbol.
+func (p *parser) currency() bool {
+	return p.consume(p.loc.Code) || p.consume(p.loc.Symbol)
+}
+
+// sign consumes an optional + or - and reports whether the value is negative and whether a sign was present.
+func (p *parser) sign() (bool, bool) {
+	switch {
+	case p.consume("-"):
+		return true, true
+	case p.consume("+"):
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+func (p *parser) peek(s string) bool {
+	return s != "" && strings.HasPrefix(p.input[p.pos:], s)
+}
+
+func (p *parser) consume(s string) bool {
+	if !p.peek(s) {
+		return false
+	}
+	p.pos += len(s)
+	return true
+}
+
+func (p *parser) digitAt(i int) bool {
+	return i < len(p.input) && isDigit(p.input[i])
+}
+
+func (p *parser) errorf(err error, format string, args ...any) error {
+	return &ParseError{Input: p.input, Offset: p.pos, Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
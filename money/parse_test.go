@@ -0,0 +1,87 @@
+package money_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestParse(t *testing.T) {
+	valid := map[string]money.Money{
+		"$1,234.56":          123456,
+		"$-1,234.56":         -123456,
+		"1234.56":            123456,
+		"-$5":                -500,
+		"(5.00)":             -500,
+		"($5.00)":            -500,
+		" +$0.5 ":            50,
+		".75":                75,
+		"12":                 1200,
+		"1234.56 $":          123456,
+		"$0.00":              0,
+		"100000000000000.00": 1e16,
+	}
+	for input, want := range valid {
+		got, err := money.Parse(input)
+		should.NotBeError(t, err, should.WithMessage(input))
+		should.BeEqual(t, got, want, should.WithMessage(input))
+	}
+	for _, m := range []money.Money{0, 5, -789, 123456, 1e16} {
+		got, err := money.Parse(m.String())
+		should.NotBeError(t, err)
+		should.BeEqual(t, got, m)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	invalid := map[string]error{
+		"":                   money.ErrSyntax,
+		"$":                  money.ErrSyntax,
+		"abc":                money.ErrSyntax,
+		"1.2.3":              money.ErrSyntax,
+		",123":               money.ErrSyntax,
+		"1,,234":             money.ErrSyntax,
+		"1,2,3":              money.ErrSyntax,
+		"12,34":              money.ErrSyntax,
+		"1,2345":             money.ErrSyntax,
+		"1234,567":           money.ErrSyntax,
+		"1,234,56.00":        money.ErrSyntax,
+		"(5.00":              money.ErrSyntax,
+		"(-5.00)":            money.ErrSyntax,
+		"1.005":              money.ErrPrecision,
+		"100000000000000.01": money.ErrOverflow,
+	}
+	for input, want := range invalid {
+		_, err := money.Parse(input)
+		should.BeErrorIs(t, err, want, should.WithMessage(input))
+	}
+	_, err := money.Parse("$12x")
+	var parseErr *money.ParseError
+	should.BeErrorAs(t, err, &parseErr)
+	should.BeEqual(t, parseErr.Offset, 3)
+	_, err = money.Parse("1,2,3")
+	should.BeErrorAs(t, err, &parseErr)
+	should.BeEqual(t, parseErr.Offset, 4)
+	_, err = money.Parse(money.Money(math.MaxInt64).String())
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestParseLocale(t *testing.T) {
+	de := money.Locale{Symbol: "€", Decimal: ",", Group: "."}
+	got, err := money.ParseLocale("1.234,56 €", de)
+	should.NotBeError(t, err)
+	should.BeEqual(t, got, money.Money(123456))
+	fr := money.Locale{Symbol: "€", Decimal: ",", Group: " "}
+	got, err = money.ParseLocale("-1 234,5 €", fr)
+	should.NotBeError(t, err)
+	should.BeEqual(t, got, money.Money(-123450))
+	got, err = money.ParseLocale("₹1,23,45,678.00", money.LocaleIN)
+	should.NotBeError(t, err)
+	should.BeEqual(t, got, money.Money(12345678_00))
+	_, err = money.ParseLocale("₹12,345,678.00", money.LocaleIN)
+	should.BeErrorIs(t, err, money.ErrSyntax)
+	_, err = money.ParseLocale("1.23,00", de)
+	should.BeErrorIs(t, err, money.ErrSyntax)
+}
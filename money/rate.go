@@ -0,0 +1,192 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Rate is a proportion such as a tax rate, discount or markup, stored exactly in
+// hundredths of a basis point, eg 7.5% is 75000. Use the constants to scale whole numbers,
+// eg 7*Percent or 750*BasisPoint.
+type Rate int64
+
+// Rate units.
+const (
+	BasisPoint Rate = 100
+	Percent    Rate = 100 * BasisPoint
+	Whole      Rate = 100 * Percent
+
+	rateDecimals = 6 // decimal places of a Rate as a fraction
+)
+
+// ParseRate parses a rate written as a percentage (7.5%), in basis points (750bp or 750bps)
+// or as a plain fraction (0.075). Rates finer than a hundredth of a basis point are rejected, as
+// are plain fractions of 1 or more, since 7 is more likely a mistake for 7% than a rate of 700%.
+func ParseRate(s string) (Rate, error) {
+	str := strings.TrimSpace(s)
+	decimals := rateDecimals
+	plain := false
+	switch {
+	case strings.HasSuffix(str, "%"):
+		str, decimals = strings.TrimSpace(strings.TrimSuffix(str, "%")), rateDecimals-2 //nolint:mnd
+	case strings.HasSuffix(str, "bps"):
+		str, decimals = strings.TrimSpace(strings.TrimSuffix(str, "bps")), rateDecimals-4 //nolint:mnd
+	case strings.HasSuffix(str, "bp"):
+		str, decimals = strings.TrimSpace(strings.TrimSuffix(str, "bp")), rateDecimals-4 //nolint:mnd
+	default:
+		plain = true
+	}
+	b, err := ParseBig(str, decimals)
+	if pe := (*ParseError)(nil); errors.As(err, &pe) {
+		return 0, &ParseError{Input: s, Offset: strings.Index(s, str) + pe.Offset, Err: pe.Err, Detail: "not a rate"}
+	}
+	units := b.Units()
+	if !units.IsInt64() {
+		return 0, &ParseError{Input: s, Offset: 0, Err: ErrOverflow, Detail: "rate too large"}
+	}
+	if r := Rate(units.Int64()); !plain || (r < Whole && r > -Whole) {
+		return r, nil
+	}
+	return 0, &ParseError{Input: s, Offset: 0, Err: ErrInvalidRate, Detail: "plain rate of 1 or more, write " + str + "% or a fraction"}
+}
+
+// String implements the stringer interface for Rate as a percentage, eg 7.5%.
+func (r Rate) String() string {
+	s := NewBig(big.NewInt(int64(r)), rateDecimals-2).Decimal() //nolint:mnd
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s + "%"
+}
+
+// Float64 returns r as a fraction, eg 0.075 for 7.5%.
+func (r Rate) Float64() float64 {
+	f, _ := r.rat().Float64()
+	return f
+}
+
+// BasisPoints returns r in whole basis points, truncating any fraction.
+func (r Rate) BasisPoints() int64 {
+	return int64(r / BasisPoint)
+}
+
+// MarshalText implements encoding.TextMarshaler as a percentage, eg 7.5%.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting anything ParseRate accepts.
+func (r *Rate) UnmarshalText(text []byte) error {
+	v, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting strings such as "7.5%" and numbers such as 0.075.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrSyntax, data)
+		}
+		return r.UnmarshalText([]byte(s))
+	default:
+		return r.UnmarshalText(data)
+	}
+}
+
+// MarshalYAML implements yaml.Marshaler as a percentage, eg 7.5%.
+func (r Rate) MarshalYAML() (any, error) {
+	return r.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting anything ParseRate accepts.
+func (r *Rate) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%w: yaml line %d is not a scalar", ErrUnsupportedType, node.Line)
+	}
+	if err := r.UnmarshalText([]byte(node.Value)); err != nil {
+		return fmt.Errorf("yaml line %d: %w", node.Line, err)
+	}
+	return nil
+}
+
+// Tax calculates the amount of tax at rate r, eg 7*Percent. The product is rounded exactly with
+// the optional mode, which defaults to RoundHalfUp. A result beyond the ceiling of ±$100 trillion
+// is clamped to it, as New does; use MulRat to get an overflow error instead.
+func (m Money) Tax(r Rate, mode ...Rounding) Money {
+	return clamp(m.times(r, mode))
+}
+
+// WithTax returns the amount with tax at rate r included, clamped to the ceiling as Tax is.
+func (m Money) WithTax(r Rate, mode ...Rounding) Money {
+	t := m.times(r, mode)
+	return clamp(t.Add(t, big.NewInt(int64(m))))
+}
+
+// Discount returns the amount of a discount at rate r, rounded with the optional mode and clamped as Tax is.
+func (m Money) Discount(r Rate, mode ...Rounding) Money {
+	return m.Tax(r, mode...)
+}
+
+// WithDiscount returns the amount less a discount at rate r, clamped to the ceiling as Tax is.
+func (m Money) WithDiscount(r Rate, mode ...Rounding) Money {
+	d := m.times(r, mode)
+	return clamp(d.Sub(big.NewInt(int64(m)), d))
+}
+
+// Markup returns the amount of a markup at rate r, rounded with the optional mode and clamped as Tax is.
+func (m Money) Markup(r Rate, mode ...Rounding) Money {
+	return m.Tax(r, mode...)
+}
+
+// WithMarkup returns the amount plus a markup at rate r, clamped to the ceiling as Tax is.
+func (m Money) WithMarkup(r Rate, mode ...Rounding) Money {
+	return m.WithTax(r, mode...)
+}
+
+// times returns m multiplied by r and rounded with mode.
+func (m Money) times(r Rate, mode []Rounding) *big.Int {
+	p := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), r.rat())
+	return roundingMode(mode).round(p)
+}
+
+// clamp returns n as Money, limited to the ceiling of ±maxValue minor units as New is.
+func clamp(n *big.Int) Money {
+	switch {
+	case n.Cmp(bigMax) > 0:
+		return Money(maxValue)
+	case n.CmpAbs(bigMax) > 0:
+		return Money(-maxValue)
+	default:
+		return Money(n.Int64())
+	}
+}
+
+// Tax calculates the amount of tax at rate r; see Money.Tax.
+func (b BigMoney) Tax(r Rate, mode ...Rounding) BigMoney {
+	return b.MulRat(r.rat(), mode...)
+}
+
+// WithTax returns the amount with tax at rate r included.
+func (b BigMoney) WithTax(r Rate, mode ...Rounding) BigMoney {
+	return b.Add(b.Tax(r, mode...))
+}
+
+// rat returns r as an exact fraction.
+func (r Rate) rat() *big.Rat {
+	return big.NewRat(int64(r), int64(Whole))
+}
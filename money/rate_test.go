@@ -0,0 +1,102 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestParseRate(t *testing.T) {
+	valid := map[string]money.Rate{
+		"7.5%":    75000,
+		" 7.5 % ": 75000,
+		"0.075":   75000,
+		"750bp":   75000,
+		"750 bps": 75000,
+		"9.975%":  99750,
+		"0.01bp":  1,
+		"100%":    money.Whole,
+		"-2%":     -2 * money.Percent,
+	}
+	for input, want := range valid {
+		got, err := money.ParseRate(input)
+		should.NotBeError(t, err, should.WithMessage(input))
+		should.BeEqual(t, got, want, should.WithMessage(input))
+	}
+	for _, input := range []string{"7", "7.5", "1", "-1", "100", " 1.0 "} {
+		_, err := money.ParseRate(input)
+		should.BeErrorIs(t, err, money.ErrInvalidRate, should.WithMessage(input))
+	}
+	_, err := money.ParseRate("0.999999")
+	should.NotBeError(t, err)
+	_, err = money.ParseRate("0.0000001")
+	should.BeErrorIs(t, err, money.ErrPrecision)
+	_, err = money.ParseRate("seven%")
+	should.BeErrorIs(t, err, money.ErrSyntax)
+	_, err = money.ParseRate("")
+	should.BeErrorIs(t, err, money.ErrSyntax)
+}
+
+func TestRateString(t *testing.T) {
+	should.BeEqual(t, money.Rate(75000).String(), "7.5%")
+	should.BeEqual(t, (15 * money.Percent).String(), "15%")
+	should.BeEqual(t, money.Rate(1).String(), "0.0001%")
+	should.BeEqual(t, money.Rate(-99750).String(), "-9.975%")
+	should.BeEqual(t, money.Rate(75000).BasisPoints(), int64(750))
+	should.BeEqual(t, money.Rate(75000).Float64(), 0.075)
+}
+
+func TestRateEncoding(t *testing.T) {
+	var table []money.TaxComponent
+	should.NotBeError(t, yaml.Unmarshal([]byte(
+		"- name: GST\n  rate: 5%\n- name: QST\n  rate: 0.09975\n  compound: true\n"), &table))
+	should.BeEqual(t, table[0].Rate, 5*money.Percent)
+	should.BeEqual(t, table[1].Rate, money.Rate(99750))
+	should.BeTrue(t, table[1].Compound)
+	var bad []money.TaxComponent
+	should.BeErrorIs(t, yaml.Unmarshal([]byte("- name: GST\n  rate: five\n"), &bad), money.ErrSyntax)
+
+	data, err := json.Marshal(table[1])
+	should.NotBeError(t, err)
+	should.BeEqual(t, string(data), `{"name":"QST","rate":"9.975%","compound":true}`)
+	var c money.TaxComponent
+	should.NotBeError(t, json.Unmarshal([]byte(`{"name":"VAT","rate":0.2}`), &c))
+	should.BeEqual(t, c.Rate, 20*money.Percent)
+
+	should.BeErrorIs(t, yaml.Unmarshal([]byte("- name: GST\n  rate: 7\n"), &bad), money.ErrInvalidRate)
+	should.BeErrorIs(t, json.Unmarshal([]byte(`{"name":"VAT","rate":20}`), &c), money.ErrInvalidRate)
+	var doc struct {
+		Rate money.Rate `toml:"rate"`
+	}
+	err = toml.Unmarshal([]byte("rate = 7\n"), &doc)
+	should.BeError(t, err)
+	should.ContainSubstring(t, err.Error(), money.ErrInvalidRate.Error()) // toml does not wrap the error
+	should.NotBeError(t, toml.Unmarshal([]byte("rate = 0.07\n"), &doc))
+	should.BeEqual(t, doc.Rate, 7*money.Percent)
+}
+
+func TestTaxClamps(t *testing.T) {
+	ceiling := money.New(math.MaxFloat64)
+	should.BeEqual(t, money.Money(math.MaxInt64).Tax(200*money.Percent), ceiling)
+	should.BeEqual(t, money.Money(math.MinInt64).Tax(200*money.Percent), -ceiling)
+	should.BeEqual(t, money.Money(math.MaxInt64).WithTax(5*money.Percent), ceiling)
+	should.BeEqual(t, money.Money(9e15).WithMarkup(50*money.Percent), ceiling)
+	should.BeEqual(t, money.Money(-9e15).WithDiscount(-50*money.Percent), -ceiling)
+	should.BeEqual(t, money.Money(9e15).WithTax(5*money.Percent), money.Money(945e13))
+}
+
+func TestRates(t *testing.T) {
+	should.BeEqual(t, money.Money(10000).Tax(75000), money.Money(750))
+	should.BeEqual(t, money.Money(250).Tax(5*money.Percent, money.RoundHalfEven), money.Money(12))
+	should.BeEqual(t, money.Money(10000).WithTax(99750), money.Money(10998))
+	should.BeEqual(t, money.Money(1999).Discount(10*money.Percent), money.Money(200))
+	should.BeEqual(t, money.Money(1999).WithDiscount(10*money.Percent), money.Money(1799))
+	should.BeEqual(t, money.Money(1000).Markup(25*money.Percent), money.Money(250))
+	should.BeEqual(t, money.Money(1000).WithMarkup(25*money.Percent), money.Money(1250))
+	should.BeEqual(t, money.Money(1000).Big().WithTax(5*money.Percent).Decimal(), "10.50")
+}
@@ -0,0 +1,89 @@
+package money
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Rounding is a mode for rounding a fractional number of cents to a whole cent.
+type Rounding int
+
+// Rounding modes; the zero value RoundHalfUp matches math.Round.
+const (
+	RoundHalfUp   Rounding = iota // nearest, ties away from zero
+	RoundHalfEven                 // nearest, ties to even (banker's rounding)
+	RoundHalfDown                 // nearest, ties towards zero
+	RoundCeiling                  // towards positive infinity
+	RoundFloor                    // towards negative infinity
+	RoundTruncate                 // towards zero
+)
+
+// String implements the stringer interface for Rounding.
+func (r Rounding) String() string {
+	switch r {
+	case RoundHalfUp:
+		return "half-up"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundHalfDown:
+		return "half-down"
+	case RoundCeiling:
+		return "ceiling"
+	case RoundFloor:
+		return "floor"
+	case RoundTruncate:
+		return "truncate"
+	default:
+		return "Rounding(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// round rounds x to an integer.
+func (r Rounding) round(x *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+	neg := x.Sign() < 0
+	// compare 2*|rem| with the denominator: -1 below half, 0 exactly half, +1 above half
+	half := new(big.Int).Lsh(rem.Abs(rem), 1).Cmp(x.Denom())
+	var away bool
+	switch r {
+	case RoundHalfEven:
+		away = half > 0 || half == 0 && q.Bit(0) == 1
+	case RoundHalfDown:
+		away = half > 0
+	case RoundCeiling:
+		away = !neg
+	case RoundFloor:
+		away = neg
+	case RoundTruncate:
+		away = false
+	default:
+		away = half >= 0
+	}
+	if !away {
+		return q
+	}
+	if neg {
+		return q.Sub(q, big.NewInt(1))
+	}
+	return q.Add(q, big.NewInt(1))
+}
+
+// roundingMode returns the first of modes, or RoundHalfUp if there are none.
+func roundingMode(modes []Rounding) Rounding {
+	if len(modes) == 0 {
+		return RoundHalfUp
+	}
+	return modes[0]
+}
+
+// ratFromFloat returns the shortest decimal representation of f as an exact rational, eg 0.15 is 15/100.
+func ratFromFloat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
@@ -0,0 +1,50 @@
+package money_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestRounding(t *testing.T) {
+	// m * 1/10 for m in -25, -15, -11, 11, 15, 25 and each mode
+	inputs := []money.Money{-25, -15, -11, 11, 15, 25}
+	want := map[money.Rounding][]money.Money{
+		money.RoundHalfUp:   {-3, -2, -1, 1, 2, 3},
+		money.RoundHalfEven: {-2, -2, -1, 1, 2, 2},
+		money.RoundHalfDown: {-2, -1, -1, 1, 1, 2},
+		money.RoundCeiling:  {-2, -1, -1, 2, 2, 3},
+		money.RoundFloor:    {-3, -2, -2, 1, 1, 2},
+		money.RoundTruncate: {-2, -1, -1, 1, 1, 2},
+	}
+	for mode, results := range want {
+		for i, m := range inputs {
+			got, err := m.MulRat(big.NewRat(1, 10), mode)
+			should.NotBeError(t, err)
+			should.BeEqual(t, got, results[i], should.WithMessagef("%v %v", mode, m))
+		}
+	}
+	should.BeEqual(t, money.RoundHalfEven.String(), "half-even")
+	should.BeEqual(t, money.Rounding(42).String(), "Rounding(42)")
+}
+
+func TestTaxRounding(t *testing.T) {
+	// 1.005 * 1000 cents is exact with decimal rates, unlike float64
+	should.BeEqual(t, money.Money(1000).Tax(money.Whole+50*money.BasisPoint), money.Money(1005))
+	should.BeEqual(t, money.Money(250).Tax(5*money.Percent), money.Money(13))
+	should.BeEqual(t, money.Money(250).Tax(5*money.Percent, money.RoundHalfEven), money.Money(12))
+	should.BeEqual(t, money.Money(101).Tax(7*money.Percent, money.RoundCeiling), money.Money(8))
+	should.BeEqual(t, money.Money(101).Tax(7*money.Percent, money.RoundFloor), money.Money(7))
+	should.BeEqual(t, money.Money(250).WithTax(5*money.Percent, money.RoundHalfEven), money.Money(262))
+}
+
+func TestDivFromStringRounding(t *testing.T) {
+	q, err := money.Money(5).Div(2, money.RoundHalfEven)
+	should.NotBeError(t, err)
+	should.BeEqual(t, q, money.Money(2))
+	m, err := money.FromString("1.005", money.RoundHalfEven)
+	should.NotBeError(t, err)
+	should.BeEqual(t, m, money.Money(100))
+}
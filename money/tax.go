@@ -0,0 +1,93 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TaxComponent is a named tax rate, eg GST at 5%.
+type TaxComponent struct {
+	Name     string `json:"name"     yaml:"name"`
+	Rate     Rate   `json:"rate"     yaml:"rate"`
+	Compound bool   `json:"compound" yaml:"compound"` // levied on the net plus preceding taxes rather than the net alone
+}
+
+// TaxLine is the amount of one tax in a TaxBreakdown.
+type TaxLine struct {
+	Name   string `json:"name"`
+	Rate   Rate   `json:"rate"`
+	Amount Money  `json:"amount"`
+}
+
+// TaxBreakdown lists each tax on an amount; Net plus the sum of Lines always equals Gross.
+type TaxBreakdown struct {
+	Net   Money
+	Lines []TaxLine
+	Tax   Money
+	Gross Money
+}
+
+// ExtractTax splits a tax inclusive amount into its net and tax parts, so that net + tax == m.
+// The net amount is rounded with the optional mode, which defaults to RoundHalfUp.
+func (m Money) ExtractTax(rate Rate, mode ...Rounding) (Money, Money, error) {
+	b, err := m.ExtractTaxes([]TaxComponent{{Name: "", Rate: rate, Compound: false}}, mode...)
+	if err != nil {
+		return 0, 0, err
+	}
+	return b.Net, b.Tax, nil
+}
+
+// ApplyTaxes calculates each tax in order on the net amount m.
+// Each line is rounded with the optional mode, which defaults to RoundHalfUp.
+func (m Money) ApplyTaxes(taxes []TaxComponent, mode ...Rounding) (TaxBreakdown, error) {
+	b := TaxBreakdown{Net: m, Lines: make([]TaxLine, 0, len(taxes)), Tax: 0, Gross: m}
+	for _, t := range taxes {
+		base := m
+		if t.Compound {
+			base = b.Gross
+		}
+		amount, err := base.MulRat(t.Rate.rat(), mode...)
+		if err != nil {
+			return TaxBreakdown{}, fmt.Errorf("tax %s: %w", t.Name, err)
+		}
+		b.Lines = append(b.Lines, TaxLine{Name: t.Name, Rate: t.Rate, Amount: amount})
+		if b.Tax, err = b.Tax.Add(amount); err != nil {
+			return TaxBreakdown{}, err
+		}
+		if b.Gross, err = b.Gross.Add(amount); err != nil {
+			return TaxBreakdown{}, err
+		}
+	}
+	return b, nil
+}
+
+// ExtractTaxes splits the tax inclusive amount m into its net amount and each tax.
+// Taxes are recalculated on the rounded net amount and any rounding residue is absorbed by Net,
+// so that Gross is always m.
+func (m Money) ExtractTaxes(taxes []TaxComponent, mode ...Rounding) (TaxBreakdown, error) {
+	// factor is gross / net, eg 1.05 for a single 5% tax
+	factor := big.NewRat(1, 1)
+	for _, t := range taxes {
+		base := big.NewRat(1, 1)
+		if t.Compound {
+			base.Set(factor)
+		}
+		factor.Add(factor, base.Mul(base, t.Rate.rat()))
+	}
+	if factor.Sign() == 0 {
+		return TaxBreakdown{}, fmt.Errorf("%w: taxes total -100%%", ErrDivideByZero)
+	}
+	net, err := m.MulRat(factor.Inv(factor), mode...)
+	if err != nil {
+		return TaxBreakdown{}, err
+	}
+	b, err := net.ApplyTaxes(taxes, mode...)
+	if err != nil {
+		return TaxBreakdown{}, err
+	}
+	if b.Net, err = m.Sub(b.Tax); err != nil {
+		return TaxBreakdown{}, err
+	}
+	b.Gross = m
+	return b, nil
+}
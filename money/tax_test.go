@@ -0,0 +1,59 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/Kairum-Labs/should"
+	"github.com/mattkasun/tools/money"
+)
+
+func TestExtractTax(t *testing.T) {
+	net, tax, err := money.Money(11500).ExtractTax(15 * money.Percent)
+	should.NotBeError(t, err)
+	should.BeEqual(t, net, money.Money(10000))
+	should.BeEqual(t, tax, money.Money(1500))
+	net, tax, err = money.Money(999).ExtractTax(7 * money.Percent)
+	should.NotBeError(t, err)
+	should.BeEqual(t, net, money.Money(934))
+	should.BeEqual(t, tax, money.Money(65))
+	_, _, err = money.Money(100).ExtractTax(-money.Whole)
+	should.BeErrorIs(t, err, money.ErrDivideByZero)
+}
+
+func TestApplyTaxes(t *testing.T) {
+	gst := money.TaxComponent{Name: "GST", Rate: 5 * money.Percent}
+	pst := money.TaxComponent{Name: "PST", Rate: 7 * money.Percent}
+	qst := money.TaxComponent{Name: "QST", Rate: 950 * money.BasisPoint, Compound: true}
+
+	stacked, err := money.Money(10000).ApplyTaxes([]money.TaxComponent{gst, pst})
+	should.NotBeError(t, err)
+	should.BeEqual(t, stacked.Lines, []money.TaxLine{
+		{Name: "GST", Rate: 5 * money.Percent, Amount: 500},
+		{Name: "PST", Rate: 7 * money.Percent, Amount: 700},
+	})
+	should.BeEqual(t, stacked.Tax, money.Money(1200))
+	should.BeEqual(t, stacked.Gross, money.Money(11200))
+
+	compound, err := money.Money(10000).ApplyTaxes([]money.TaxComponent{gst, qst})
+	should.NotBeError(t, err)
+	should.BeEqual(t, compound.Lines[1].Amount, money.Money(998)) // 9.5% of 105.00
+	should.BeEqual(t, compound.Gross, money.Money(11498))
+
+	_, err = money.Money(1e16).ApplyTaxes([]money.TaxComponent{gst})
+	should.BeErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestExtractTaxes(t *testing.T) {
+	gst := money.TaxComponent{Name: "GST", Rate: 5 * money.Percent}
+	qst := money.TaxComponent{Name: "QST", Rate: 950 * money.BasisPoint, Compound: true}
+	for _, gross := range []money.Money{11498, 1, 999, 123457, -5000} {
+		b, err := gross.ExtractTaxes([]money.TaxComponent{gst, qst}, money.RoundHalfEven)
+		should.NotBeError(t, err)
+		should.BeEqual(t, b.Gross, gross)
+		should.BeEqual(t, b.Net+b.Lines[0].Amount+b.Lines[1].Amount, gross)
+		should.BeEqual(t, b.Net+b.Tax, gross)
+	}
+	b, err := money.Money(11498).ExtractTaxes([]money.TaxComponent{gst, qst})
+	should.NotBeError(t, err)
+	should.BeEqual(t, b.Net, money.Money(10000))
+}